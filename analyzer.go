@@ -6,33 +6,75 @@ import (
 	"go/token"
 	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
 )
 
 // Analyzer はテスト関数の順序がソースコードの宣言順序と一致しているかを検証する。
-var Analyzer = &analysis.Analyzer{
-	Name: "testalign",
-	Doc:  "テスト関数の順序がソースコードの宣言順序と一致しているかを検証する",
-	Run:  run,
-	FactTypes: []analysis.Fact{
-		(*SourceOrderFact)(nil),
-	},
+// 既定の設定で NewAnalyzer を呼び出したものと同じ。
+var Analyzer = NewAnalyzer()
+
+// Option は NewAnalyzer の設定を変更する。
+type Option func(*analyzer)
+
+// WithMatcher はテスト関数とソース関数の対応付けに使うMatcherを指定する。
+// 指定したMatcherは設定ファイルの命名規則（naming）より優先される。
+func WithMatcher(m Matcher) Option {
+	return func(a *analyzer) {
+		a.matcher = m
+	}
+}
+
+// analyzer はアナライザごとの設定とフラグの値を保持する。
+type analyzer struct {
+	matcher Matcher
+	flags   flagValues
 }
 
-func run(pass *analysis.Pass) (any, error) {
+// NewAnalyzer はオプションを適用したアナライザを生成する。
+// 独自の命名規則を使うラッパーは、WithMatcher を渡したアナライザを singlechecker.Main などで実行する。
+func NewAnalyzer(opts ...Option) *analysis.Analyzer {
+	a := &analyzer{}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	an := &analysis.Analyzer{
+		Name: "testalign",
+		Doc:  "テスト関数の順序がソースコードの宣言順序と一致しているかを検証する",
+		Run:  a.run,
+		FactTypes: []analysis.Fact{
+			(*SourceOrderFact)(nil),
+		},
+	}
+	a.flags.register(&an.Flags)
+
+	return an
+}
+
+func (a *analyzer) run(pass *analysis.Pass) (any, error) {
 	// テストバイナリのmainパッケージはスキップ
-	if pass.Pkg.Name() == "main" {
+	if pass.Pkg.Name() == "main" || len(pass.Files) == 0 {
 		return nil, nil
 	}
 
-	// ファイルをソースファイルとテストファイルに分類
+	cfg, err := a.loadPassConfig(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	// ファイルをソースファイルとテストファイルに分類（無視パターンに一致するファイルは除外）
 	sourceFiles := make(map[string]*ast.File)
 	testFiles := make(map[string]*ast.File)
 
 	for _, file := range pass.Files {
 		pos := pass.Fset.Position(file.Pos())
+		if cfg.IsIgnored(pos.Filename) {
+			continue
+		}
+
 		fileName := filepath.Base(pos.Filename)
 		if IsTestFile(fileName) {
 			testFiles[fileName] = file
@@ -50,68 +92,259 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	}
 
+	// コンストラクタや型ごとのメソッドを設定に従ってまとめる
+	arrangeSourceFuncs(cfg, sourceFiles, pass.TypesInfo, allSourceFuncs)
+
+	// プラットフォーム別のソースファイルをまとめる（ビルド制約で除外されたファイルも含める）
+	var families map[string][]SourceFunc
+	if len(sourceFiles) > 0 {
+		excluded, err := parseExcludedFiles(pass, cfg)
+		if err != nil {
+			return nil, err
+		}
+		families = platformFamilies(pass.Fset, sourceFiles, excluded, allSourceFuncs)
+	}
+
 	// ソース関数情報をFactとしてエクスポート（外部テストパッケージ用）
 	if len(allSourceFuncs) > 0 {
-		fact := &SourceOrderFact{FileToFuncs: allSourceFuncs}
+		fact := &SourceOrderFact{FileToFuncs: allSourceFuncs, Families: families}
 		pass.ExportPackageFact(fact)
 	}
 
 	// 外部テストパッケージの場合、Factからソース関数をインポート
 	if len(sourceFiles) == 0 && len(testFiles) > 0 {
-		allSourceFuncs = importSourceFuncsFromFact(pass)
+		allSourceFuncs, families = importSourceFuncsFromFact(pass)
 	}
 
-	// テストファイルごとに検証
-	for testFileName, testFile := range testFiles {
-		testFuncs := ExtractTestFuncs(testFile, pass.Fset)
+	// 型を単位とする対応付け（ファイル単位の対応より優先する）
+	families = applyTypeMapping(cfg, slices.Sorted(maps.Keys(testFiles)), allSourceFuncs, families)
+
+	// testifyスイートの型を検出（型の宣言とsuite.Runの呼び出しは別ファイルにあってもよい）
+	suites := findSuiteTypes(slices.Collect(maps.Values(testFiles)), cfg.Suite)
+
+	// テストファイルごとに検証（診断の順序を安定させるためファイル名順に処理する）
+	packageFuncs := concatSourceFuncs(allSourceFuncs)
+	declared := declaredFuncNames(testFiles)
+	matchesBySource := make(map[string][]MatchResult)
+	for _, testFileName := range slices.Sorted(maps.Keys(testFiles)) {
+		testFile := testFiles[testFileName]
+		// TestMain・ヘルパー関数・型などの配置の検証
+		if cfg.Enabled(CheckLayout) {
+			checkLayout(pass, cfg, testFile)
+		}
+
+		testFuncs := extractTestFuncs(testFile, pass.Fset, cfg.TestPrefixes, suites)
 		if len(testFuncs) == 0 {
 			continue
 		}
 
+		// 別のテストファイルに置くべきテスト関数の検出
+		if cfg.Enabled(CheckWrongFile) {
+			if err := checkWrongFile(pass, cfg, testFileName, testFiles, testFuncs, allSourceFuncs, families, suites); err != nil {
+				return nil, err
+			}
+		}
+
 		// 対応するソースファイルの関数を収集
-		sourceFuncs := collectSourceFuncsForTestFile(testFileName, allSourceFuncs)
+		sourceFuncs := collectSourceFuncsForTestFile(cfg.sourceFileIn(testFileName, allSourceFuncs, families), allSourceFuncs, families)
 		if len(sourceFuncs) == 0 {
 			continue
 		}
 
 		// マッチング
-		matches := MatchTestFuncs(testFuncs, sourceFuncs)
+		matches := matchTestFuncs(testFuncs, sourceFuncs, cfg.matcher())
+
+		// アンブレラテスト（例: TestService）のt.Runサブテストのマッチング
+		umbrellas := FindUmbrellaTests(testFile, pass.TypesInfo, testFuncs, sourceFuncs, cfg.Naming)
+		subtestMatches := make([][]MatchResult, len(umbrellas))
+		for i, u := range umbrellas {
+			subtestMatches[i] = matchTestFuncs(u.Subtests, sourceFuncs, cfg.matcher())
+		}
+
+		if sourceFileName := cfg.sourceFileIn(testFileName, allSourceFuncs, families); mappedSourceFuncs(sourceFileName, allSourceFuncs, families) != nil {
+			matchesBySource[sourceFileName] = append(matchesBySource[sourceFileName], matches...)
+			for _, sm := range subtestMatches {
+				matchesBySource[sourceFileName] = append(matchesBySource[sourceFileName], sm...)
+			}
+		}
+
+		// 対応先を失ったテスト関数の検出
+		if cfg.Enabled(CheckOrphan) {
+			for _, o := range FindOrphans(matches, packageFuncs, cfg.matcher(), cfg.Naming) {
+				reportOrphan(pass, o, testFile, declared)
+			}
+		}
+
+		// 複数のソース関数に同じ強さでマッチするテスト名の検出（独自のMatcherを使う場合は行わない）
+		if cfg.Resolve == ResolveTypes && cfg.Matcher == nil {
+			checkAmbiguity(pass, cfg, testFuncs, sourceFuncs)
+		}
+
+		// テスト名が示すソース関数を参照しないテスト関数の検出
+		if cfg.Enabled(CheckExercise) {
+			checkExercise(pass, cfg, slices.Collect(maps.Values(testFiles)), matches)
+		}
+
+		// 型ごとのテストのまとまりの検証
+		if cfg.Enabled(CheckGrouping) {
+			checkGrouping(pass, matches)
+		}
+
+		// 同じソース関数のテストのまとまりとシナリオの並び順の検証
+		if cfg.Enabled(CheckContiguity) {
+			checkContiguity(pass, cfg, matches)
+		}
 
 		// 順序検証
-		violations := DetectOrderViolations(matches, sourceFuncs)
+		if cfg.Enabled(CheckOrder) {
+			if err := checkOrder(pass, cfg, testFile, matches, umbrellas, subtestMatches, sourceFuncs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 同じソースファイルに対応するテストファイル間の入り組みの検出
+	if cfg.Enabled(CheckCrossFile) {
+		checkCrossFile(pass, matchesBySource, allSourceFuncs, families)
+	}
+
+	// テストのないソース関数の検出
+	if cfg.Enabled(CheckMissing) {
+		checkMissing(pass, cfg, sourceFiles, testFiles, allSourceFuncs, families, matchesBySource)
+	}
+
+	return nil, nil
+}
+
+// checkOrder はテストファイルの順序を検証し、違反を報告する。
+// アンブレラテストのt.Runサブテストの順序も検証し、違反はt.Runの呼び出し位置に報告する。
+// 抑制ディレクティブの付いたテスト関数は検証対象から除外し、不要になったディレクティブも報告する。
+// アンブレラテストに付いたディレクティブはそのサブテストにも適用する。
+func checkOrder(pass *analysis.Pass, cfg *Config, file *ast.File, matches []MatchResult, umbrellas []Umbrella, subtestMatches [][]MatchResult, sourceFuncs []SourceFunc) error {
+	src, err := pass.ReadFile(pass.Fset.File(file.Pos()).Name())
+	if err != nil {
+		return err
+	}
+
+	ds := ParseDirectives(file, pass.Fset)
 
-		// 診断報告
+	subtestViolations := make([][]OrderViolation, len(umbrellas))
+	for i := range umbrellas {
+		subtestViolations[i] = detectOrderViolations(subtestMatches[i], sourceFuncs, cfg.KindOrder)
+	}
+
+	if ds.File == nil {
+		violations := detectOrderViolations(filterIgnored(matches, ds, token.NoPos), sourceFuncs, cfg.KindOrder)
 		for _, v := range violations {
-			reportViolation(pass, v)
+			reportViolation(pass, v, file, src)
+		}
+
+		for i, u := range umbrellas {
+			if ds.Ignored(u.TestFunc) {
+				continue
+			}
+
+			for _, v := range subtestViolations[i] {
+				reportViolation(pass, v, file, src)
+			}
 		}
 	}
 
-	return nil, nil
+	if cfg.Enabled(CheckUnusedDirective) {
+		for _, d := range unusedDirectives(matches, sourceFuncs, ds, cfg.KindOrder) {
+			if !suppressesSubtests(d, ds, umbrellas, subtestViolations) {
+				reportUnusedDirective(pass, d, file, src)
+			}
+		}
+	}
+
+	return nil
+}
+
+// suppressesSubtests はディレクティブdがサブテストの順序違反を抑制しているか判定する。
+func suppressesSubtests(d *Directive, ds Directives, umbrellas []Umbrella, subtestViolations [][]OrderViolation) bool {
+	for i, u := range umbrellas {
+		if len(subtestViolations[i]) == 0 {
+			continue
+		}
+
+		if d == ds.File || ds.Funcs[u.TestFunc.Pos] == d {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadPassConfig は解析対象パッケージに適用される設定を読み込む。
+// -config フラグが指定されている場合はそのファイルを使い、
+// それ以外はパッケージのディレクトリを起点に設定ファイルを探索する。
+// WithMatcher でMatcherが指定されている場合は、設定のMatcherを置き換える。
+func (a *analyzer) loadPassConfig(pass *analysis.Pass) (*Config, error) {
+	var cfg *Config
+	var err error
+
+	if a.flags.configPath != "" {
+		cfg, err = LoadConfigFile(a.flags.configPath)
+	} else {
+		cfg, err = LoadConfig(filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name()))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	a.flags.apply(cfg, &pass.Analyzer.Flags)
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	if a.matcher != nil {
+		cfg.Matcher = a.matcher
+	}
+
+	return cfg, nil
 }
 
 // collectSourceFuncsForTestFile はテストファイルに対応するソースファイルの関数を収集する。
-// 対応ルール: foo_test.go → foo.go
-// 対応するソースファイルがない場合は、全ソースファイルの関数を結合して返す。
-func collectSourceFuncsForTestFile(testFileName string, allSourceFuncs map[string][]SourceFunc) []SourceFunc {
-	sourceFileName := SourceFileForTest(testFileName)
+// sourceFileNameは対応ルール（既定: foo_test.go → foo.go）で求めたソースファイル名。
+// 対応するソースファイルがない場合は、全ソースファイルの関数をファイル名順・宣言順に結合して返す。
+func collectSourceFuncsForTestFile(sourceFileName string, allSourceFuncs, families map[string][]SourceFunc) []SourceFunc {
+	// まず直接対応（プラットフォーム別のファイルはまとめたもの）を試みる
+	if funcs := mappedSourceFuncs(sourceFileName, allSourceFuncs, families); funcs != nil {
+		return funcs
+	}
+
+	// 対応するソースファイルがない場合、全ソースファイルの関数をファイル名順に結合
+	return concatSourceFuncs(allSourceFuncs)
+}
 
-	// まず直接対応を試みる
-	if funcs, ok := allSourceFuncs[sourceFileName]; ok {
+// mappedSourceFuncs はソースファイル名に対応する関数を返す。
+// プラットフォーム別のファイル（例: conn_linux.go、conn_windows.go）がある場合は、
+// それらをまとめた関数一覧を返す。対応するソースファイルがない場合はnilを返す。
+func mappedSourceFuncs(sourceFileName string, allSourceFuncs, families map[string][]SourceFunc) []SourceFunc {
+	if funcs, ok := families[sourceFileName]; ok {
 		return funcs
 	}
 
-	// 対応するソースファイルがない場合、全ソースファイルの関数を結合
+	return allSourceFuncs[sourceFileName]
+}
+
+// concatSourceFuncs は全ソースファイルの関数をファイル名順・宣言順に結合して返す。
+func concatSourceFuncs(allSourceFuncs map[string][]SourceFunc) []SourceFunc {
 	var all []SourceFunc
-	for _, funcs := range allSourceFuncs {
-		all = append(all, funcs...)
+	for _, fileName := range slices.Sorted(maps.Keys(allSourceFuncs)) {
+		all = append(all, allSourceFuncs[fileName]...)
 	}
 
 	return all
 }
 
-// importSourceFuncsFromFact は依存パッケージからFactをインポートし、ソース関数を取得する。
-func importSourceFuncsFromFact(pass *analysis.Pass) map[string][]SourceFunc {
+// importSourceFuncsFromFact は依存パッケージからFactをインポートし、
+// ソースファイルごとの関数とプラットフォーム別のファイルをまとめた関数を取得する。
+func importSourceFuncsFromFact(pass *analysis.Pass) (map[string][]SourceFunc, map[string][]SourceFunc) {
 	result := make(map[string][]SourceFunc)
+	families := make(map[string][]SourceFunc)
 
 	// 外部テストパッケージのパスは "<path>_test" の形式
 	// ソースパッケージのパスは "<path>"
@@ -125,44 +358,121 @@ func importSourceFuncsFromFact(pass *analysis.Pass) map[string][]SourceFunc {
 		var fact SourceOrderFact
 		if pass.ImportPackageFact(imp, &fact) {
 			maps.Copy(result, fact.FileToFuncs)
+			maps.Copy(families, fact.Families)
 		}
 	}
 
-	return result
+	return result, families
 }
 
 // reportViolation は順序違反の診断メッセージを生成・報告する。
-func reportViolation(pass *analysis.Pass, v OrderViolation) {
-	srcPos := formatSourcePos(pass.Fset, v.SourceFunc)
-	precedingPos := ""
-	precedingName := ""
-
-	if v.PrecedingTest != nil && v.PrecedingTest.SourceFunc != nil {
-		precedingPos = formatSourcePos(pass.Fset, *v.PrecedingTest.SourceFunc)
-		precedingName = v.PrecedingTest.TestFunc.Name
+// 移動先が特定できる場合は、テスト関数を移動するSuggestedFixを添付する。
+func reportViolation(pass *analysis.Pass, v OrderViolation, file *ast.File, src []byte) {
+	anchor, where := v.After, "after"
+	if anchor == nil {
+		anchor, where = v.Before, "before"
 	}
 
 	var msg string
-	if precedingName != "" {
+	if anchor != nil && anchor.SourceFunc != nil {
 		msg = fmt.Sprintf(
-			"%s corresponds to %s (%s) but appears before %s which corresponds to %s (%s)",
-			v.TestFunc.Name,
+			"%s corresponds to %s (%s) and should be placed %s %s which corresponds to %s (%s)",
+			formatTestRef(v.TestFunc),
 			formatFuncRef(v.SourceFunc),
-			srcPos,
-			precedingName,
-			formatFuncRef(*v.PrecedingTest.SourceFunc),
-			precedingPos,
+			formatSourcePos(pass.Fset, v.SourceFunc),
+			where,
+			formatTestRef(anchor.TestFunc),
+			formatFuncRef(*anchor.SourceFunc),
+			formatSourcePos(pass.Fset, *anchor.SourceFunc),
 		)
 	} else {
 		msg = fmt.Sprintf(
 			"%s corresponds to %s (%s) but is out of order",
-			v.TestFunc.Name,
+			formatTestRef(v.TestFunc),
 			formatFuncRef(v.SourceFunc),
-			srcPos,
+			formatSourcePos(pass.Fset, v.SourceFunc),
 		)
 	}
 
-	pass.Reportf(v.TestFunc.Pos, "%s", msg)
+	diag := analysis.Diagnostic{
+		Pos:     v.TestFunc.Pos,
+		Message: msg,
+	}
+
+	if anchor != nil {
+		tf := pass.Fset.File(file.Pos())
+		if fix, ok := buildMoveFix(tf, file, src, v.TestFunc, anchor.TestFunc, v.After != nil); ok {
+			diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+	}
+
+	pass.Report(diag)
+}
+
+// reportUnusedDirective は不要になった抑制ディレクティブを報告する。
+// ディレクティブを削除するSuggestedFixを添付する。
+func reportUnusedDirective(pass *analysis.Pass, d *Directive, file *ast.File, src []byte) {
+	var msg string
+	switch {
+	case d.FileLevel && d.Pos >= firstDeclPos(file):
+		msg = "unused //testalign:ignore-file directive: it must appear before the first declaration"
+	case d.FileLevel:
+		msg = "unused //testalign:ignore-file directive: the file has no order violations"
+	case d.Target == "":
+		msg = "unused //testalign:ignore directive: it is not attached to a function"
+	default:
+		msg = fmt.Sprintf("unused //testalign:ignore directive: %s has no order violation to suppress", d.Target)
+	}
+
+	tf := pass.Fset.File(file.Pos())
+	pass.Report(analysis.Diagnostic{
+		Pos:            d.Pos,
+		End:            d.End,
+		Message:        msg,
+		SuggestedFixes: []analysis.SuggestedFix{buildRemoveCommentFix(tf, src, d.Pos, d.End)},
+	})
+}
+
+// reportOrphan は対応先を失ったテスト関数を報告する。
+// 名前の近いソース関数がある場合は、テスト関数の名前を変更するSuggestedFixを添付する。
+// 変更後の名前がパッケージのテストファイルですでに宣言されている場合（declared）は、候補だけを示す。
+func reportOrphan(pass *analysis.Pass, o Orphan, file *ast.File, declared map[string]bool) {
+	diag := analysis.Diagnostic{
+		Pos:     o.TestFunc.Pos,
+		Message: fmt.Sprintf("%s does not correspond to any source function", o.TestFunc.Name),
+	}
+
+	if o.Suggestion != nil {
+		diag.Message += fmt.Sprintf("; did you mean %s (%s)?", o.NewName, formatFuncRef(*o.Suggestion))
+
+		renamed := o.TestFunc
+		renamed.Name = o.NewName
+		if decl := findFuncDecl(file, o.TestFunc.Pos); decl != nil && !declared[formatTestRef(renamed)] {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: fmt.Sprintf("Rename %s to %s", o.TestFunc.Name, o.NewName),
+				TextEdits: []analysis.TextEdit{
+					{Pos: decl.Name.Pos(), End: decl.Name.End(), NewText: []byte(o.NewName)},
+				},
+			}}
+		}
+	}
+
+	pass.Report(diag)
+}
+
+// declaredFuncNames はテストファイルで宣言された関数の名前の集合を返す。
+// メソッドは "ReceiverType.Name" の形式とする（formatTestRef と同じ）。
+func declaredFuncNames(testFiles map[string]*ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, file := range testFiles {
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				names[funcName(fd)] = true
+			}
+		}
+	}
+
+	return names
 }
 
 // formatFuncRef はソース関数の参照文字列を返す。
@@ -176,6 +486,22 @@ func formatFuncRef(sf SourceFunc) string {
 	return sf.Name
 }
 
+// formatTestRef はテスト関数の参照文字列を返す。
+// testifyスイートのメソッドの場合: "SuiteType.TestName"
+// サブテストの場合: "TestName/SubtestName"
+// テスト関数の場合: "TestName"
+func formatTestRef(tf TestFunc) string {
+	if tf.Parent != "" {
+		return tf.Parent + "/" + tf.Name
+	}
+
+	if tf.Suite != "" {
+		return tf.Suite + "." + tf.Name
+	}
+
+	return tf.Name
+}
+
 // formatSourcePos はソース関数のファイル位置を "filename:line" 形式で返す。
 func formatSourcePos(fset *token.FileSet, sf SourceFunc) string {
 	if sf.Pos.IsValid() {
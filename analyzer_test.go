@@ -1,6 +1,7 @@
 package testalign_test
 
 import (
+	"strings"
 	"testing"
 
 	testalign "github.com/basashifx/go-testalign"
@@ -19,6 +20,26 @@ func TestAnalyzer(t *testing.T) {
 		"multifile",
 		"pkgfuncs",
 		"multi_receiver",
+		"fallback",
+		"configured",
+		"missing",
+		"gotests",
+		"testifysuite",
+		"umbrella",
+		"examples",
+		"decls",
+		"constructors",
+		"grouping",
+		"typeorder",
+		"contiguity",
+		"layout",
+		"layoutcaller",
+		"platform",
+		"multitest",
+		"typemapping",
+		"typemissing",
+		"resolve",
+		"exercise",
 	}
 
 	for _, tt := range tests {
@@ -32,3 +53,26 @@ func TestAnalyzer_ExternalTestPackage(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, testalign.Analyzer, "externalapi")
 }
+
+func TestAnalyzer_SuggestedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, testalign.Analyzer, "fix", "misplaced", "suppress", "orphan", "wrongfile", "kindinterleaved", "kindsegregated")
+}
+
+func TestNewAnalyzer_WithMatcher(t *testing.T) {
+	// "TestServiceCreate" のように区切り文字なしで連結する命名規則
+	matcher := testalign.MatcherFunc(func(tf testalign.TestFunc, sourceFuncs []testalign.SourceFunc) *testalign.SourceFunc {
+		var best *testalign.SourceFunc
+		for i, sf := range sourceFuncs {
+			name := sf.ReceiverType + sf.Name
+			if strings.HasPrefix(tf.TargetName(), name) && (best == nil || len(name) > len(best.ReceiverType+best.Name)) {
+				best = &sourceFuncs[i]
+			}
+		}
+
+		return best
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, testalign.NewAnalyzer(testalign.WithMatcher(matcher)), "custommatcher")
+}
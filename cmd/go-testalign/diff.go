@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext はunified diffのハンクに含める前後の行数。
+const diffContext = 3
+
+// unifiedDiff はoldとnewの行単位のunified diffを返す。差分がない場合は空文字を返す。
+func unifiedDiff(oldName, newName string, old, new []byte) string {
+	a := splitLines(old)
+	b := splitLines(new)
+	ops := diffLines(a, b)
+
+	var buf bytes.Buffer
+
+	for start := 0; start < len(ops); {
+		// 次の変更行を探す
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+
+		// コンテキストを含めたハンクの範囲を決める
+		hunkStart := max(first-diffContext, start)
+		hunkEnd := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				hunkEnd = i + 1
+				continue
+			}
+			if i-hunkEnd >= 2*diffContext {
+				break
+			}
+		}
+		hunkEnd = min(hunkEnd+diffContext, len(ops))
+
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "diff %s %s\n--- %s\n+++ %s\n", oldName, newName, oldName, newName)
+		}
+
+		writeHunk(&buf, ops, hunkStart, hunkEnd)
+		start = hunkEnd
+	}
+
+	return buf.String()
+}
+
+// diffOp はdiffの1行分の操作を表す（' ': 共通, '-': 削除, '+': 追加）。
+type diffOp struct {
+	kind    byte
+	line    string
+	oldLine int // 1始まりの旧ファイル行番号
+	newLine int // 1始まりの新ファイル行番号
+}
+
+// diffLines は最長共通部分列に基づいて行単位の編集列を計算する。
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] は a[i:] と b[j:] の最長共通部分列の長さ
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i], oldLine: i + 1, newLine: j + 1})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', line: a[i], oldLine: i + 1, newLine: j + 1})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: b[j], oldLine: i + 1, newLine: j + 1})
+			j++
+		}
+	}
+
+	return ops
+}
+
+// writeHunk はops[start:end]を1つのハンクとして出力する。
+func writeHunk(buf *bytes.Buffer, ops []diffOp, start, end int) {
+	oldStart, newStart := ops[start].oldLine, ops[start].newLine
+	oldCount, newCount := 0, 0
+
+	for _, op := range ops[start:end] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+
+	// 空の範囲は直前の行番号で表す
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+
+	fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+
+	for _, op := range ops[start:end] {
+		buf.WriteByte(op.kind)
+		buf.WriteString(op.line)
+		buf.WriteByte('\n')
+	}
+}
+
+// splitLines はテキストを改行で分割する。末尾の改行による空行は含めない。
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
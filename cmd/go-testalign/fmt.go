@@ -0,0 +1,234 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	testalign "github.com/basashifx/go-testalign"
+	"golang.org/x/tools/go/packages"
+)
+
+// runFmt はfmtサブコマンドを実行し、終了コードを返す。
+// 各テストファイルをソース関数の宣言順序に並べ替えて書き戻す。
+// -d の場合は書き込まずにunified diffを出力し、-l の場合はファイル名のみ出力する。
+func runFmt(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	flags.StringVar(&configPath, "config", "", "path to the configuration file (default: discover "+testalign.ConfigFileName+" from the module root)")
+	diffMode := flags.Bool("d", false, "display diffs instead of rewriting files")
+	listMode := flags.Bool("l", false, "list files whose order differs from the source order")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: go-testalign fmt [-d] [-l] [-config file] [packages]\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	// 構文だけを読み込み、型情報が必要な設定のディレクトリだけを型チェックして読み込み直す
+	fset := token.NewFileSet()
+	dirs, err := loadDirs(fset, patterns, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "go-testalign fmt: %v\n", err)
+		return 1
+	}
+
+	cfgs := make(map[string]*testalign.Config)
+	var typedDirs []string
+	for _, dir := range slices.Sorted(maps.Keys(dirs)) {
+		cfg, err := loadConfig(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "go-testalign fmt: %v\n", err)
+			return 1
+		}
+
+		cfgs[dir] = cfg
+		if cfg.NeedsTypesInfo() {
+			typedDirs = append(typedDirs, dir)
+		}
+	}
+
+	if len(typedDirs) > 0 {
+		typed, err := loadDirs(fset, typedDirs, true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "go-testalign fmt: %v\n", err)
+			return 1
+		}
+
+		for _, dir := range typedDirs {
+			if typed[dir] != nil {
+				dirs[dir] = typed[dir]
+			}
+		}
+	}
+
+	exitCode := 0
+
+	for _, dir := range slices.Sorted(maps.Keys(dirs)) {
+		cfg := cfgs[dir]
+		cfg.TypesInfo = dirs[dir].info
+		cfg.PlatformFiles = dirs[dir].platformFiles
+
+		rewritten, err := testalign.RewriteFiles(fset, dirs[dir].files, os.ReadFile, cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "go-testalign fmt: %v\n", err)
+			return 1
+		}
+
+		for _, path := range slices.Sorted(maps.Keys(rewritten)) {
+			out := rewritten[path]
+
+			switch {
+			case *listMode:
+				fmt.Println(path)
+			case *diffMode:
+				// 移動先として新たに作成されるファイルは空のファイルとの差分を出力する
+				src, err := os.ReadFile(path)
+				if err != nil && !errors.Is(err, fs.ErrNotExist) {
+					fmt.Fprintf(os.Stderr, "go-testalign fmt: %v\n", err)
+					return 1
+				}
+
+				fmt.Print(unifiedDiff(path+".orig", path, src, out))
+			default:
+				if err := writeFile(path, out); err != nil {
+					fmt.Fprintf(os.Stderr, "go-testalign fmt: %v\n", err)
+					exitCode = 1
+				}
+			}
+		}
+	}
+
+	return exitCode
+}
+
+// loadedDir はディレクトリごとに読み込んだパッケージの情報。
+type loadedDir struct {
+	files         []*ast.File // ソースファイルとテストファイルの構文木
+	info          *types.Info // 型情報（定義の情報のみ。型チェックせずに読み込んだ場合はnil）
+	platformFiles []*ast.File // ビルド制約で除外されたソースファイルの構文木
+}
+
+// loadDirs はパターンに一致するパッケージ（テストを含む）を読み込み、
+// ディレクトリごとの構文木と型情報を返す。同一ファイルが複数のパッケージに現れる場合は1つにまとめる。
+// withTypesがfalseの場合は型チェックを行わず、構文だけを読み込む。
+func loadDirs(fset *token.FileSet, patterns []string, withTypes bool) (map[string]*loadedDir, error) {
+	mode := packages.NeedName | packages.NeedFiles | packages.NeedSyntax
+	if withTypes {
+		mode |= packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesInfo
+	}
+
+	cfg := &packages.Config{
+		Mode:  mode,
+		Tests: true,
+		Fset:  fset,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("failed to load packages")
+	}
+
+	dirs := make(map[string]*loadedDir)
+	dirOf := func(path string) *loadedDir {
+		dir := filepath.Dir(path)
+		if dirs[dir] == nil {
+			dirs[dir] = &loadedDir{}
+		}
+		return dirs[dir]
+	}
+	seen := make(map[string]bool)
+
+	for _, pkg := range pkgs {
+		// テストバイナリのmainパッケージはスキップ（アナライザと同じ扱い）
+		if pkg.Name == "main" {
+			continue
+		}
+
+		for _, file := range pkg.Syntax {
+			path := fset.File(file.Pos()).Name()
+			if seen[path] {
+				continue
+			}
+
+			seen[path] = true
+			d := dirOf(path)
+			d.files = append(d.files, file)
+		}
+
+		// 採用した構文木の識別子の定義は、その構文木を含むパッケージの型情報にある
+		if len(pkg.Syntax) > 0 && pkg.TypesInfo != nil {
+			d := dirOf(fset.File(pkg.Syntax[0].Pos()).Name())
+			if d.info == nil {
+				d.info = &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+			}
+			maps.Copy(d.info.Defs, pkg.TypesInfo.Defs)
+		}
+
+		// ビルド制約で除外されたソースファイル（他のプラットフォーム向けの実装）は構文だけ読み込む
+		for _, path := range pkg.IgnoredFiles {
+			if seen[path] || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				continue
+			}
+
+			file, err := parser.ParseFile(fset, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+			if err != nil || file.Name.Name != pkg.Name {
+				continue
+			}
+
+			seen[path] = true
+			d := dirOf(path)
+			d.platformFiles = append(d.platformFiles, file)
+		}
+	}
+
+	return dirs, nil
+}
+
+// configPath は -config フラグで指定された設定ファイルのパス。
+var configPath string
+
+// loadConfig はディレクトリに適用される設定を読み込む。
+func loadConfig(dir string) (*testalign.Config, error) {
+	if configPath != "" {
+		return testalign.LoadConfigFile(configPath)
+	}
+
+	return testalign.LoadConfig(dir)
+}
+
+// writeFile は元のファイルのパーミッションを保ったままファイルを書き換える。
+// ファイルが存在しない場合は新たに作成する。
+func writeFile(path string, data []byte) error {
+	perm := fs.FileMode(0o644)
+
+	info, err := os.Stat(path)
+	switch {
+	case err == nil:
+		perm = info.Mode().Perm()
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	return os.WriteFile(path, data, perm)
+}
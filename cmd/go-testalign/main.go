@@ -1,10 +1,17 @@
 package main
 
 import (
+	"os"
+
 	testalign "github.com/basashifx/go-testalign"
 	"golang.org/x/tools/go/analysis/singlechecker"
 )
 
 func main() {
+	// サブコマンド: go-testalign fmt [-d] [-l] [-config file] [packages]
+	if len(os.Args) > 1 && os.Args[1] == "fmt" {
+		os.Exit(runFmt(os.Args[2:]))
+	}
+
 	singlechecker.Main(testalign.Analyzer)
 }
@@ -0,0 +1,370 @@
+package testalign
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName は設定ファイルの名前。
+const ConfigFileName = ".testalign.yaml"
+
+// チェック名の一覧。Config.Checks のキーとして使う。
+const (
+	CheckOrder           = "order"            // テスト関数の順序検証
+	CheckUnusedDirective = "unused_directive" // 不要になった抑制ディレクティブの検出
+	CheckMissing         = "missing"          // テストのないソース関数の検出（既定で無効）
+	CheckOrphan          = "orphan"           // 対応先を失ったテスト関数の検出（既定で無効）
+	CheckWrongFile       = "wrong_file"       // 別のテストファイルに置くべきテスト関数の検出（既定で無効）
+	CheckGrouping        = "grouping"         // 同じ型のテスト関数が連続しているかの検証（既定で無効）
+	CheckContiguity      = "contiguity"       // 同じソース関数のテスト関数が連続しているかの検証（既定で無効）
+	CheckLayout          = "layout"           // TestMain・ヘルパー関数・型などの配置の検証（既定で無効）
+	CheckCrossFile       = "cross_file"       // 同じソースファイルに対応するテストファイル間の入り組みの検出（既定で無効）
+	CheckExercise        = "exercise"         // テスト名が示すソース関数を参照しないテスト関数の検出（既定で無効）
+)
+
+// Config は設定ファイル（.testalign.yaml）の内容を表す。
+//
+// 設定ファイルはモジュールルートから対象パッケージのディレクトリまでの各階層で探索され、
+// ディレクトリが深いファイルほど優先される（指定された項目だけが上書きされる）。
+type Config struct {
+	TestPrefixes  []string        `yaml:"test_prefixes"`  // テスト関数のプレフィックス
+	FileMapping   []FileMapping   `yaml:"file_mapping"`   // テストファイルからソースファイルへの対応ルール
+	TestSuffixes  []string        `yaml:"test_suffixes"`  // 対応するソースファイルがない場合にテストファイル名から取り除く接尾辞
+	Mapping       string          `yaml:"mapping"`        // ファイル対応の方式（MappingFile、MappingType）
+	Resolve       string          `yaml:"resolve"`        // テスト対象の解決方式（ResolveNames、ResolveTypes）
+	Ignore        []string        `yaml:"ignore"`         // 検証対象外とするファイルのglobパターン
+	Naming        Naming          `yaml:"naming"`         // テスト名の命名規則
+	Checks        map[string]bool `yaml:"checks"`         // チェックごとの有効/無効
+	Missing       MissingConfig   `yaml:"missing"`        // missingチェックの設定
+	Suite         SuiteConfig     `yaml:"suite"`          // testifyスイートの設定
+	Constructors  bool            `yaml:"constructors"`   // コンストラクタ（NewX/newX）を型Xのテストとしてまとめる
+	Grouping      GroupingConfig  `yaml:"grouping"`       // 型ごとのテストのまとまりと並び順の設定
+	ScenarioOrder []string        `yaml:"scenario_order"` // 同じソース関数のテストのシナリオ名の並び順（contiguityチェックで使う）
+	KindOrder     KindOrder       `yaml:"kind_order"`     // テストの種類（Test、Benchmarkなど）の並べ方
+	Layout        LayoutConfig    `yaml:"layout"`         // テスト関数以外の宣言の配置（layoutチェックで使う）
+	Exercise      ExerciseConfig  `yaml:"exercise"`       // exerciseチェックの設定
+
+	// Matcher はテスト関数とソース関数の対応付けに使うMatcher。
+	// nilの場合はNamingに従う NamingMatcher を使う。設定ファイルからは指定できない。
+	Matcher Matcher `yaml:"-"`
+
+	// TypesInfo はConstructorsが有効な場合のコンストラクタの判定と、Resolve が ResolveTypes の場合の
+	// レシーバー型の解決に使う型情報（RewriteFiles でのみ使う）。nilの場合はどちらも行わない。アナライザは解析中のパッケージの型情報を使う。
+	TypesInfo *types.Info `yaml:"-"`
+
+	// PlatformFiles はビルド制約で除外されたソースファイル（例: Linux上の conn_windows.go）の構文木（RewriteFiles でのみ使う）。
+	// プラットフォーム別のソースファイルをまとめてテストファイルに対応付けるために使う。
+	// アナライザは解析中のパッケージの除外ファイル（pass.IgnoredFiles）を読み込む。
+	PlatformFiles []*ast.File `yaml:"-"`
+
+	root string // ignoreパターンの基準となるモジュールルート
+}
+
+// FileMapping はテストファイル名からソースファイル名への対応ルールを表す。
+// パターン中の "*" は1回だけ使用でき、テストファイル側でマッチした部分がソースファイル側に代入される。
+type FileMapping struct {
+	Test   string `yaml:"test"`   // 例: "*_test.go"
+	Source string `yaml:"source"` // 例: "*.go"
+}
+
+// Naming はテスト名からソース関数を特定するための命名規則を表す。
+type Naming struct {
+	Style             string `yaml:"style"`              // 修飾名の形式（NamingUnderscore、NamingCamel、NamingGotests）
+	Separator         string `yaml:"separator"`          // レシーバー型とメソッド名、サブテスト名の区切り
+	ScenarioSeparator string `yaml:"scenario_separator"` // 修飾名とシナリオ名の区切り（空の場合はSeparator）
+	UnexportedPrefix  string `yaml:"unexported_prefix"`  // 非公開関数のテスト名に付けるプレフィックス
+}
+
+// 命名規則の形式。Naming.Style に指定する。
+const (
+	NamingUnderscore = "underscore" // TestService_Create（既定）
+	NamingCamel      = "camel"      // TestServiceCreate
+	NamingGotests    = "gotests"    // TestService_Create、非公開の型は Test_service_Create
+)
+
+// defaultTestSuffixes はテストファイル名から取り除く既定の接尾辞
+// （例: foo_internal_test.go、foo_bench_test.go、foo_integration_test.go → foo.go）。
+var defaultTestSuffixes = []string{"_internal", "_bench", "_integration"}
+
+// defaultNaming は既定の命名規則（"Type_Method"、非公開関数は "_name"）。
+var defaultNaming = Naming{Style: NamingUnderscore, Separator: "_", UnexportedPrefix: "_"}
+
+// DefaultConfig は既定の設定を返す。
+func DefaultConfig() *Config {
+	return &Config{
+		TestPrefixes: slices.Clone(testPrefixes),
+		FileMapping:  []FileMapping{{Test: "*_test.go", Source: "*.go"}},
+		TestSuffixes: slices.Clone(defaultTestSuffixes),
+		Mapping:      MappingFile,
+		Resolve:      ResolveNames,
+		Naming:       defaultNaming,
+		Suite:        SuiteConfig{Suffixes: slices.Clone(defaultSuiteSuffixes)},
+		Grouping:     GroupingConfig{TypeOrder: TypeOrderMethod},
+		KindOrder:    KindOrder{Layout: KindLayoutNone, Kinds: slices.Clone(defaultKindOrder)},
+		Layout:       LayoutConfig{TestMain: LayoutTop, Helpers: LayoutEnd, Declarations: LayoutBeforeUse},
+		Checks:       map[string]bool{CheckOrder: true, CheckUnusedDirective: true},
+	}
+}
+
+// LoadConfig はdirに適用される設定を読み込む。
+// モジュールルート（go.modのあるディレクトリ）からdirまでの各階層の設定ファイルを順に適用する。
+// 設定ファイルが1つもない場合は既定の設定を返す。
+func LoadConfig(dir string) (*Config, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := DefaultConfig()
+	cfg.root = findModuleRoot(dir)
+
+	for _, d := range dirsFromRoot(cfg.root, dir) {
+		if err := cfg.apply(filepath.Join(d, ConfigFileName)); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// LoadConfigFile は指定された設定ファイルを既定の設定に適用して読み込む。
+func LoadConfigFile(path string) (*Config, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := DefaultConfig()
+	cfg.root = filepath.Dir(path)
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.apply(path); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Enabled は指定されたチェックが有効かどうかを返す。
+func (c *Config) Enabled(check string) bool {
+	return c.Checks[check]
+}
+
+// NeedsTypesInfo はRewriteFiles にTypesInfo が必要な設定か判定する
+// （Constructors が有効、またはResolve が ResolveTypes の場合）。
+func (c *Config) NeedsTypesInfo() bool {
+	return c.Constructors || c.Resolve == ResolveTypes
+}
+
+// IsIgnored はファイルが無視パターンに一致するか判定する。
+// パターンはファイル名、およびモジュールルートからの相対パスと照合する。
+func (c *Config) IsIgnored(path string) bool {
+	base := filepath.Base(path)
+	rel := base
+	if c.root != "" {
+		if r, err := filepath.Rel(c.root, path); err == nil {
+			rel = filepath.ToSlash(r)
+		}
+	}
+
+	for _, pattern := range c.Ignore {
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matcher は対応付けに使うMatcherを返す。
+func (c *Config) matcher() Matcher {
+	if c.Matcher != nil {
+		return c.Matcher
+	}
+
+	return NamingMatcher{Naming: c.Naming}
+}
+
+// SourceFileFor はファイル対応ルールに従ってテストファイルに対応するソースファイル名を返す。
+// どのルールにも一致しない場合は SourceFileForTest の結果を返す。
+func (c *Config) SourceFileFor(testFile string) string {
+	for _, rule := range c.FileMapping {
+		if stem, ok := matchWildcard(rule.Test, testFile); ok {
+			return strings.Replace(rule.Source, "*", stem, 1)
+		}
+	}
+
+	return SourceFileForTest(testFile)
+}
+
+// TestFileFor はファイル対応ルールを逆向きに適用し、ソースファイルに対応するテストファイル名を返す。
+// どのルールにも一致しない場合は "foo.go" → "foo_test.go" とする。
+func (c *Config) TestFileFor(sourceFile string) string {
+	for _, rule := range c.FileMapping {
+		if stem, ok := matchWildcard(rule.Source, sourceFile); ok {
+			return strings.Replace(rule.Test, "*", stem, 1)
+		}
+	}
+
+	return strings.TrimSuffix(sourceFile, ".go") + "_test.go"
+}
+
+// sourceFileIn はテストファイルに対応するパッケージ内のソースファイル名を返す。
+// SourceFileFor で求めたソースファイルがパッケージにない場合は、その名前から
+// TestSuffixes の接尾辞を取り除いたソースファイル（例: foo_bench_test.go → foo.go）を探す。
+// どちらも見つからない場合は SourceFileFor の結果を返す。
+func (c *Config) sourceFileIn(testFile string, allSourceFuncs, families map[string][]SourceFunc) string {
+	name := c.SourceFileFor(testFile)
+	if mappedSourceFuncs(name, allSourceFuncs, families) != nil {
+		return name
+	}
+
+	stem := strings.TrimSuffix(name, ".go")
+	for _, suffix := range c.TestSuffixes {
+		base, ok := strings.CutSuffix(stem, suffix)
+		if !ok || base == "" {
+			continue
+		}
+
+		if candidate := base + ".go"; mappedSourceFuncs(candidate, allSourceFuncs, families) != nil {
+			return candidate
+		}
+	}
+
+	return name
+}
+
+// apply は設定ファイルを読み込み、記述されている項目で設定を上書きする。
+// ファイルが存在しない場合は何もしない。
+func (c *Config) apply(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// 値のない "checks:" はマップをnilにするため、それまでの設定を引き継ぐ
+	checks := c.Checks
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if c.Checks == nil {
+		c.Checks = checks
+	}
+
+	if err := c.validate(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// validate は列挙値の設定項目に既知の値が指定されているか検証する。
+func (c *Config) validate() error {
+	switch c.Naming.Style {
+	case "", NamingUnderscore, NamingCamel, NamingGotests:
+	default:
+		return fmt.Errorf("unknown naming style %q", c.Naming.Style)
+	}
+
+	switch c.Mapping {
+	case "", MappingFile, MappingType:
+	default:
+		return fmt.Errorf("unknown mapping %q", c.Mapping)
+	}
+
+	switch c.Resolve {
+	case "", ResolveNames, ResolveTypes:
+	default:
+		return fmt.Errorf("unknown resolve mode %q", c.Resolve)
+	}
+
+	switch c.Grouping.TypeOrder {
+	case "", TypeOrderMethod, TypeOrderDeclaration:
+	default:
+		return fmt.Errorf("unknown grouping type order %q", c.Grouping.TypeOrder)
+	}
+
+	switch c.KindOrder.Layout {
+	case "", KindLayoutNone, KindLayoutInterleaved, KindLayoutSegregated:
+	default:
+		return fmt.Errorf("unknown kind order layout %q", c.KindOrder.Layout)
+	}
+
+	for _, v := range []struct {
+		key, value string
+		allowed    []string
+	}{
+		{"layout.test_main", c.Layout.TestMain, []string{LayoutTop, LayoutBottom, LayoutNone}},
+		{"layout.helpers", c.Layout.Helpers, []string{LayoutAfterCaller, LayoutEnd, LayoutNone}},
+		{"layout.declarations", c.Layout.Declarations, []string{LayoutBeforeUse, LayoutNone}},
+	} {
+		if v.value != "" && !slices.Contains(v.allowed, v.value) {
+			return fmt.Errorf("unknown %s %q", v.key, v.value)
+		}
+	}
+
+	return nil
+}
+
+// findModuleRoot はdirから親方向にgo.modを探し、見つかったディレクトリを返す。
+// 見つからない場合はdirを返す。
+func findModuleRoot(dir string) string {
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d
+		}
+
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// dirsFromRoot はrootからdirまでのディレクトリをroot側から順に返す。
+func dirsFromRoot(root, dir string) []string {
+	var dirs []string
+	for d := dir; ; d = filepath.Dir(d) {
+		dirs = append(dirs, d)
+		if d == root || filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	slices.Reverse(dirs)
+
+	return dirs
+}
+
+// matchWildcard は "*" を1つ含むパターンとnameを照合し、"*" にマッチした部分を返す。
+func matchWildcard(pattern, name string) (string, bool) {
+	prefix, suffix, ok := strings.Cut(pattern, "*")
+	if !ok {
+		return "", pattern == name
+	}
+
+	if len(name) < len(prefix)+len(suffix) || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return "", false
+	}
+
+	return name[len(prefix) : len(name)-len(suffix)], true
+}
@@ -0,0 +1,254 @@
+package testalign
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("ディレクトリ作成失敗: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("ファイル書き込み失敗: %v", err)
+	}
+}
+
+func TestLoadConfig_Default(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example\n")
+
+	cfg, err := LoadConfig(root)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	if !slices.Equal(cfg.TestPrefixes, testPrefixes) {
+		t.Errorf("TestPrefixes: got %v, want %v", cfg.TestPrefixes, testPrefixes)
+	}
+	if cfg.Naming != defaultNaming {
+		t.Errorf("Naming: got %+v, want %+v", cfg.Naming, defaultNaming)
+	}
+	if !cfg.Enabled(CheckOrder) {
+		t.Errorf("Enabled(%q): got false, want true", CheckOrder)
+	}
+}
+
+func TestLoadConfig_DirectoryOverride(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example\n")
+	writeFile(t, filepath.Join(root, ConfigFileName), `
+test_prefixes: [Test]
+naming:
+  separator: "__"
+ignore: ["*_gen_test.go"]
+`)
+	writeFile(t, filepath.Join(root, "sub", ConfigFileName), `
+naming:
+  unexported_prefix: "_x_"
+checks:
+  order: false
+`)
+
+	cfg, err := LoadConfig(filepath.Join(root, "sub"))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	// ルートの設定が引き継がれる
+	if !slices.Equal(cfg.TestPrefixes, []string{"Test"}) {
+		t.Errorf("TestPrefixes: got %v, want [Test]", cfg.TestPrefixes)
+	}
+	if cfg.Naming.Separator != "__" {
+		t.Errorf("Naming.Separator: got %q, want %q", cfg.Naming.Separator, "__")
+	}
+	// サブディレクトリの設定で上書きされる
+	if cfg.Naming.UnexportedPrefix != "_x_" {
+		t.Errorf("Naming.UnexportedPrefix: got %q, want %q", cfg.Naming.UnexportedPrefix, "_x_")
+	}
+	if cfg.Enabled(CheckOrder) {
+		t.Errorf("Enabled(%q): got true, want false", CheckOrder)
+	}
+	if !cfg.IsIgnored(filepath.Join(root, "sub", "foo_gen_test.go")) {
+		t.Errorf("IsIgnored: 無視パターンに一致しない")
+	}
+}
+
+func TestLoadConfig_EmptyChecks(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example\n")
+	writeFile(t, filepath.Join(root, ConfigFileName), "checks:\n")
+
+	cfg, err := LoadConfig(root)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	// 値のない checks は既定の設定を引き継ぎ、フラグで上書きできる
+	var fs flag.FlagSet
+	var v flagValues
+	v.register(&fs)
+	if err := fs.Set("missing", "true"); err != nil {
+		t.Fatalf("フラグ設定失敗: %v", err)
+	}
+	v.apply(cfg, &fs)
+
+	if !cfg.Enabled(CheckOrder) || !cfg.Enabled(CheckMissing) {
+		t.Errorf("Checks: got %v, want order と missing が有効", cfg.Checks)
+	}
+}
+
+func TestLoadConfig_InvalidYAML(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example\n")
+	writeFile(t, filepath.Join(root, ConfigFileName), "test_prefixes: {")
+
+	if _, err := LoadConfig(root); err == nil {
+		t.Error("LoadConfig: エラーが返されない")
+	}
+}
+
+func TestLoadConfig_UnknownNamingStyle(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example\n")
+	writeFile(t, filepath.Join(root, ConfigFileName), "naming:\n  style: kebab\n")
+
+	if _, err := LoadConfig(root); err == nil {
+		t.Error("LoadConfig: エラーが返されない")
+	}
+}
+
+func TestLoadConfig_UnknownTypeOrder(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example\n")
+	writeFile(t, filepath.Join(root, ConfigFileName), "grouping:\n  type_order: alphabetical\n")
+
+	if _, err := LoadConfig(root); err == nil {
+		t.Error("LoadConfig: エラーが返されない")
+	}
+}
+
+func TestLoadConfig_UnknownMapping(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example\n")
+	writeFile(t, filepath.Join(root, ConfigFileName), "mapping: package\n")
+
+	if _, err := LoadConfig(root); err == nil {
+		t.Error("LoadConfig: エラーが返されない")
+	}
+}
+
+func TestLoadConfig_LayoutNone(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example\n")
+	writeFile(t, filepath.Join(root, ConfigFileName), "checks:\n  layout: true\n")
+	writeFile(t, filepath.Join(root, "legacy", ConfigFileName), "layout:\n  helpers: none\n")
+
+	cfg, err := LoadConfig(filepath.Join(root, "legacy"))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	// ディレクトリの設定で1つの規則だけを無効にできる
+	want := LayoutConfig{TestMain: LayoutTop, Helpers: LayoutNone, Declarations: LayoutBeforeUse}
+	if cfg.Layout != want {
+		t.Errorf("Layout: got %+v, want %+v", cfg.Layout, want)
+	}
+}
+
+func TestConfig_IsIgnored(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.root = "/repo"
+	cfg.Ignore = []string{"*_integration_test.go", "internal/legacy/*"}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/repo/pkg/foo_integration_test.go", true},
+		{"/repo/internal/legacy/foo_test.go", true},
+		{"/repo/internal/foo_test.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.IsIgnored(tt.path); got != tt.want {
+			t.Errorf("IsIgnored(%q): got %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_SourceFileFor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FileMapping = []FileMapping{
+		{Test: "*_internal_test.go", Source: "*.go"},
+		{Test: "*_test.go", Source: "*.go"},
+	}
+
+	tests := []struct {
+		testFile string
+		want     string
+	}{
+		{"foo_internal_test.go", "foo.go"},
+		{"foo_test.go", "foo.go"},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.SourceFileFor(tt.testFile); got != tt.want {
+			t.Errorf("SourceFileFor(%q): got %q, want %q", tt.testFile, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_TestFileFor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FileMapping = []FileMapping{
+		{Test: "*_spec_test.go", Source: "spec/*.go"},
+		{Test: "*_test.go", Source: "*.go"},
+	}
+
+	tests := []struct {
+		sourceFile string
+		want       string
+	}{
+		{"foo.go", "foo_test.go"},
+		{"spec/bar.go", "bar_spec_test.go"},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.TestFileFor(tt.sourceFile); got != tt.want {
+			t.Errorf("TestFileFor(%q): got %q, want %q", tt.sourceFile, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_sourceFileIn(t *testing.T) {
+	cfg := DefaultConfig()
+	allSourceFuncs := map[string][]SourceFunc{
+		"store.go":          {{Name: "Get"}},
+		"store_internal.go": {{Name: "index"}},
+	}
+
+	tests := []struct {
+		testFile string
+		want     string
+	}{
+		{"store_test.go", "store.go"},
+		{"store_bench_test.go", "store.go"},
+		{"store_integration_test.go", "store.go"},
+		// 接尾辞を取り除く前のソースファイルがあればそちらを優先する
+		{"store_internal_test.go", "store_internal.go"},
+		// 対応するソースファイルがない場合は SourceFileFor の結果のまま
+		{"cache_bench_test.go", "cache_bench.go"},
+		{"store_e2e_test.go", "store_e2e.go"},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.sourceFileIn(tt.testFile, allSourceFuncs, nil); got != tt.want {
+			t.Errorf("sourceFileIn(%q): got %q, want %q", tt.testFile, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// markConstructors は型情報からコンストラクタ（NewX/newX という名前で X または *X を返す関数）を探し、
+// allSourceFuncs のソース関数に生成する型名（Constructs）を設定したうえで、型宣言の直後に並べ替える。
+// filesはソースファイル名から構文木へのマッピング。
+func markConstructors(files map[string]*ast.File, info *types.Info, allSourceFuncs map[string][]SourceFunc) {
+	for _, fileName := range slices.Sorted(maps.Keys(files)) {
+		funcs := allSourceFuncs[fileName]
+		if len(funcs) == 0 {
+			continue
+		}
+
+		for _, decl := range files[fileName].Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv != nil {
+				continue
+			}
+
+			typeName := constructedType(funcDecl, info)
+			if typeName == "" {
+				continue
+			}
+
+			for i := range funcs {
+				if funcs[i].Pos == funcDecl.Pos() {
+					funcs[i].Constructs = typeName
+				}
+			}
+		}
+
+		allSourceFuncs[fileName] = groupConstructors(funcs)
+	}
+}
+
+// constructedType は関数がコンストラクタであれば生成する型名を返す。
+// 最初の戻り値が同じパッケージの名前付き型 X（または *X）で、関数名が "New"/"new" の後に
+// 型名（先頭を大文字にしたもの）を続けたものである場合にコンストラクタとみなす。
+func constructedType(decl *ast.FuncDecl, info *types.Info) string {
+	fn, ok := info.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return ""
+	}
+
+	results := fn.Signature().Results()
+	if results.Len() == 0 {
+		return ""
+	}
+
+	typ := types.Unalias(results.At(0).Type())
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = types.Unalias(ptr.Elem())
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != fn.Pkg() {
+		return ""
+	}
+
+	typeName := named.Obj().Name()
+	for _, prefix := range []string{"New", "new"} {
+		if rest, ok := strings.CutPrefix(decl.Name.Name, prefix); ok && rest == upperFirst(typeName) {
+			return typeName
+		}
+	}
+
+	return ""
+}
+
+// groupConstructors はコンストラクタを同じファイル内の型宣言の直後に移した一覧を返す。
+// 同じ型のコンストラクタ同士は宣言順を保ち、型がファイル内で宣言されていない場合は元の位置に残す。
+func groupConstructors(funcs []SourceFunc) []SourceFunc {
+	declared := make(map[string]bool)
+	for _, sf := range funcs {
+		if sf.Kind == DeclType {
+			declared[sf.Name] = true
+		}
+	}
+
+	ctors := make(map[string][]SourceFunc)
+	var rest []SourceFunc
+
+	for _, sf := range funcs {
+		if sf.Constructs != "" && declared[sf.Constructs] {
+			ctors[sf.Constructs] = append(ctors[sf.Constructs], sf)
+			continue
+		}
+
+		rest = append(rest, sf)
+	}
+
+	grouped := make([]SourceFunc, 0, len(funcs))
+	for _, sf := range rest {
+		grouped = append(grouped, sf)
+		if sf.Kind == DeclType {
+			grouped = append(grouped, ctors[sf.Name]...)
+		}
+	}
+
+	return grouped
+}
@@ -0,0 +1,28 @@
+package testalign
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGroupConstructors(t *testing.T) {
+	funcs := []SourceFunc{
+		{Name: "Service", Kind: DeclType},
+		{Name: "Create", ReceiverType: "Service"},
+		{Name: "NewService", Constructs: "Service"},
+		{Name: "NewServiceWithOptions"},
+		// 型がファイル内にないコンストラクタは元の位置に残る
+		{Name: "NewClient", Constructs: "Client"},
+		{Name: "NewDefaultService", Constructs: "Service"},
+	}
+
+	var got []string
+	for _, sf := range groupConstructors(funcs) {
+		got = append(got, sf.Name)
+	}
+
+	want := []string{"Service", "NewService", "NewDefaultService", "Create", "NewServiceWithOptions", "NewClient"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
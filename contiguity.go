@@ -0,0 +1,165 @@
+package testalign
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Separation は同じソース関数に対応するテスト関数の間に、別のテスト関数が挟まっていることを表す。
+type Separation struct {
+	TestFunc   TestFunc   // 別のテスト関数の後に現れたテスト関数
+	SourceFunc SourceFunc // 対応するソース関数
+	Previous   TestFunc   // 同じソース関数に対応する直前のテスト関数
+	Separator  TestFunc   // 間に挟まった最初のテスト関数
+}
+
+// FindSeparatedTests はテスト関数の並びから、同じソース関数に対応するテストが連続していない箇所を返す。
+// matchesはテストファイル内のすべてのテスト関数のマッチ結果（対応なしを含む）を宣言順に並べたもの。
+func FindSeparatedTests(matches []MatchResult) []Separation {
+	var separations []Separation
+	last := make(map[token.Pos]int)
+
+	for i, m := range matches {
+		if m.SourceFunc == nil {
+			continue
+		}
+
+		if j, ok := last[m.SourceFunc.Pos]; ok && j != i-1 {
+			separations = append(separations, Separation{
+				TestFunc:   m.TestFunc,
+				SourceFunc: *m.SourceFunc,
+				Previous:   matches[j].TestFunc,
+				Separator:  matches[j+1].TestFunc,
+			})
+		}
+
+		last[m.SourceFunc.Pos] = i
+	}
+
+	return separations
+}
+
+// MisorderedScenario は同じソース関数のテストがシナリオ名の並び順に従っていないことを表す。
+type MisorderedScenario struct {
+	TestFunc TestFunc // 前に置くべきテスト関数
+	Scenario string   // TestFuncのシナリオ名（シナリオなしの場合は空）
+	Before   TestFunc // TestFuncより前にある、後に置くべきテスト関数
+}
+
+// FindMisorderedScenarios は同じソース関数に対応するテスト関数が、orderで指定されたシナリオ名の順に
+// 並んでいない箇所を返す。シナリオのないテストは最初に置くものとし、orderにないシナリオは判定に影響しない。
+// シナリオ名はorderの要素と一致するか、要素にシナリオの区切りで続く名前（例: "Error_NotFound"）であれば対応する。
+func FindMisorderedScenarios(matches []MatchResult, order []string, naming Naming) []MisorderedScenario {
+	if len(order) == 0 {
+		return nil
+	}
+
+	type ranked struct {
+		tf   TestFunc
+		rank int
+	}
+
+	var misordered []MisorderedScenario
+	highest := make(map[token.Pos]ranked)
+
+	for _, m := range matches {
+		if m.SourceFunc == nil {
+			continue
+		}
+
+		scenario, ok := naming.scenarioOf(m.TestFunc, *m.SourceFunc)
+		if !ok {
+			continue
+		}
+
+		rank := scenarioRank(scenario, order, naming)
+		if rank < 0 {
+			continue
+		}
+
+		if h, ok := highest[m.SourceFunc.Pos]; ok && h.rank > rank {
+			misordered = append(misordered, MisorderedScenario{TestFunc: m.TestFunc, Scenario: scenario, Before: h.tf})
+			continue
+		}
+
+		highest[m.SourceFunc.Pos] = ranked{tf: m.TestFunc, rank: rank}
+	}
+
+	return misordered
+}
+
+// scenarioOf はテスト名のうち、対応するソース関数の修飾名に続くシナリオ名を返す。
+// シナリオのないテストは空文字を返し、テスト名が修飾名から始まらない場合（独自のMatcherなど）はokがfalse。
+func (n Naming) scenarioOf(tf TestFunc, sf SourceFunc) (scenario string, ok bool) {
+	target := tf.TargetName()
+	if tf.TargetType != "" && sf.ReceiverType == tf.TargetType {
+		target = n.QualifiedName(SourceFunc{ReceiverType: tf.TargetType, Name: target})
+	}
+
+	for _, qname := range n.matchNames(sf) {
+		if target == qname {
+			return "", true
+		}
+
+		if n.hasScenario(target, qname) {
+			return strings.TrimPrefix(target[len(qname):], n.scenarioSeparator()), true
+		}
+	}
+
+	return "", false
+}
+
+// scenarioRank はシナリオ名の並び順を返す。シナリオなしは0、orderのi番目に対応する場合はi+1、
+// どれにも対応しない場合は-1を返す。
+func scenarioRank(scenario string, order []string, naming Naming) int {
+	if scenario == "" {
+		return 0
+	}
+
+	for i, s := range order {
+		if scenario == s || naming.hasScenario(scenario, s) {
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// splitByKind はマッチ結果を種類ごとに分け、種類の順序で返す。各グループ内は元の順序を保つ。
+func splitByKind(matches []MatchResult, kinds KindOrder) [][]MatchResult {
+	groups := make([][]MatchResult, len(kinds.Kinds)+1)
+	for _, m := range matches {
+		r := kinds.rank(m.TestFunc)
+		groups[r] = append(groups[r], m)
+	}
+
+	return groups
+}
+
+// checkContiguity は同じソース関数に対応するテスト関数が連続していない箇所と、
+// シナリオ名の並び順に従っていない箇所を報告する。
+// 種類ごとにまとめる並べ方（KindLayoutSegregated）では、種類ごとに連続しているかを検証する。
+func checkContiguity(pass *analysis.Pass, cfg *Config, matches []MatchResult) {
+	groups := [][]MatchResult{matches}
+	if cfg.KindOrder.Layout == KindLayoutSegregated {
+		groups = splitByKind(matches, cfg.KindOrder)
+	}
+
+	for _, group := range groups {
+		for _, s := range FindSeparatedTests(group) {
+			pass.Reportf(s.TestFunc.Pos, "%s is separated from %s, which also corresponds to %s, by %s",
+				formatTestRef(s.TestFunc), formatTestRef(s.Previous), formatFuncRef(s.SourceFunc), formatTestRef(s.Separator))
+		}
+	}
+
+	for _, m := range FindMisorderedScenarios(matches, cfg.ScenarioOrder, cfg.Naming) {
+		pass.Report(analysis.Diagnostic{
+			Pos: m.TestFunc.Pos,
+			Message: fmt.Sprintf("%s should be placed before %s (scenario order: %s)",
+				formatTestRef(m.TestFunc), formatTestRef(m.Before), strings.Join(cfg.ScenarioOrder, ", ")),
+		})
+	}
+}
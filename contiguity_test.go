@@ -0,0 +1,60 @@
+package testalign
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindSeparatedTests(t *testing.T) {
+	get := SourceFunc{Name: "Get", ReceiverType: "Handler", Pos: 1}
+	put := SourceFunc{Name: "Put", ReceiverType: "Handler", Pos: 2}
+
+	matches := []MatchResult{
+		{TestFunc: TestFunc{Name: "TestHandler_Get"}, SourceFunc: &get},
+		{TestFunc: TestFunc{Name: "TestHandler_Get_Error"}, SourceFunc: &get},
+		{TestFunc: TestFunc{Name: "TestHandler_Put"}, SourceFunc: &put},
+		{TestFunc: TestFunc{Name: "TestIntegration"}},
+		{TestFunc: TestFunc{Name: "TestHandler_Put_Error"}, SourceFunc: &put},
+		{TestFunc: TestFunc{Name: "TestHandler_Get_Timeout"}, SourceFunc: &get},
+	}
+
+	got := FindSeparatedTests(matches)
+
+	want := []Separation{
+		{TestFunc: matches[4].TestFunc, SourceFunc: put, Previous: matches[2].TestFunc, Separator: matches[3].TestFunc},
+		{TestFunc: matches[5].TestFunc, SourceFunc: get, Previous: matches[1].TestFunc, Separator: matches[2].TestFunc},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestNaming_scenarioOf(t *testing.T) {
+	method := SourceFunc{Name: "Create", ReceiverType: "Service"}
+	ctor := SourceFunc{Name: "NewService", Constructs: "Service"}
+
+	tests := []struct {
+		naming   Naming
+		tf       TestFunc
+		sf       SourceFunc
+		scenario string
+		ok       bool
+	}{
+		{defaultNaming, TestFunc{Name: "TestService_Create"}, method, "", true},
+		{defaultNaming, TestFunc{Name: "TestService_Create_Error_NotFound"}, method, "Error_NotFound", true},
+		{Naming{Style: NamingCamel}, TestFunc{Name: "TestServiceCreateError"}, method, "Error", true},
+		{Naming{Separator: "_", ScenarioSeparator: "__"}, TestFunc{Name: "TestService_Create__Error"}, method, "Error", true},
+		// testifyスイートのメソッドはスイートの対象の型で修飾して判定する
+		{defaultNaming, TestFunc{Name: "TestCreate_Error", Prefix: "Test", Suite: "ServiceSuite", TargetType: "Service"}, method, "Error", true},
+		{defaultNaming, TestFunc{Name: "TestService_New_Error"}, ctor, "Error", true},
+		// 独自のMatcherで対応付けたテストなど、修飾名から始まらない場合
+		{defaultNaming, TestFunc{Name: "TestCreateService"}, method, "", false},
+	}
+
+	for _, tt := range tests {
+		scenario, ok := tt.naming.scenarioOf(tt.tf, tt.sf)
+		if scenario != tt.scenario || ok != tt.ok {
+			t.Errorf("%s: got (%q, %v), want (%q, %v)", tt.tf.Name, scenario, ok, tt.scenario, tt.ok)
+		}
+	}
+}
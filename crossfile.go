@@ -0,0 +1,99 @@
+package testalign
+
+import (
+	"maps"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// CrossFileOverlap は同じソースファイルに対応する複数のテストファイルの間で、
+// テスト関数の担当範囲が入り組んでいることを表す。
+type CrossFileOverlap struct {
+	TestFunc   TestFunc   // 別のテストファイルの範囲内にあるソース関数のテスト関数
+	SourceFunc SourceFunc // TestFuncに対応するソース関数
+	File       string     // 範囲が重なる別のテストファイル
+	Previous   TestFunc   // Fileで、宣言順がSourceFuncの直前のソース関数の同じ種類のテスト関数
+	Next       TestFunc   // Fileで、宣言順がSourceFuncの直後のソース関数の同じ種類のテスト関数
+}
+
+// FindCrossFileOverlaps は同じソースファイルに対応するテストファイル群（例: foo_test.go と foo_internal_test.go）の
+// マッチ結果から、別のテストファイルが担当する宣言の範囲の内側にあるソース関数のテストを返す。
+// 各テストファイルがソースファイルの宣言順の連続した範囲を担当していれば報告はない。
+// 範囲は種類（Test、Benchmarkなど）ごとに比べるため、ベンチマークだけのファイルはテストの範囲と重なってもよい。
+// sourceFuncsは宣言順の基準とするソース関数で、t.Runサブテストとマッチしないテスト関数は判定に使わない。
+func FindCrossFileOverlaps(matches []MatchResult, sourceFuncs []SourceFunc) []CrossFileOverlap {
+	sourceIndex := buildSourceIndex(sourceFuncs)
+
+	type indexed struct {
+		m   MatchResult
+		idx int
+	}
+
+	byFile := make(map[string][]indexed)
+	for _, m := range matches {
+		if m.SourceFunc == nil || m.TestFunc.Parent != "" {
+			continue
+		}
+
+		if idx, ok := sourceIndex[m.SourceFunc.Pos]; ok {
+			byFile[m.TestFunc.FileName] = append(byFile[m.TestFunc.FileName], indexed{m, idx})
+		}
+	}
+
+	files := slices.Sorted(maps.Keys(byFile))
+
+	var overlaps []CrossFileOverlap
+
+	for _, file := range files {
+		for _, t := range byFile[file] {
+			for _, other := range files {
+				if other == file {
+					continue
+				}
+
+				// 別のファイルで宣言順が前後に最も近いテストを探す
+				var prev, next *indexed
+				for i, o := range byFile[other] {
+					if testKind(o.m.TestFunc) != testKind(t.m.TestFunc) {
+						continue
+					}
+					if o.idx < t.idx && (prev == nil || o.idx > prev.idx) {
+						prev = &byFile[other][i]
+					}
+					if o.idx > t.idx && (next == nil || o.idx < next.idx) {
+						next = &byFile[other][i]
+					}
+				}
+
+				if prev != nil && next != nil {
+					overlaps = append(overlaps, CrossFileOverlap{
+						TestFunc:   t.m.TestFunc,
+						SourceFunc: *t.m.SourceFunc,
+						File:       other,
+						Previous:   prev.m.TestFunc,
+						Next:       next.m.TestFunc,
+					})
+
+					break
+				}
+			}
+		}
+	}
+
+	return overlaps
+}
+
+// checkCrossFile は同じソースファイルに対応する複数のテストファイルの間で入り組んだテスト関数を報告する。
+// matchesBySourceは、ソースファイル名からそのファイルに対応するテストファイル群のマッチ結果へのマッピング。
+func checkCrossFile(pass *analysis.Pass, matchesBySource map[string][]MatchResult, allSourceFuncs, families map[string][]SourceFunc) {
+	for _, sourceFileName := range slices.Sorted(maps.Keys(matchesBySource)) {
+		sourceFuncs := mappedSourceFuncs(sourceFileName, allSourceFuncs, families)
+
+		for _, o := range FindCrossFileOverlaps(matchesBySource[sourceFileName], sourceFuncs) {
+			pass.Reportf(o.TestFunc.Pos, "%s corresponds to %s (%s), which falls between %s and %s in %s",
+				formatTestRef(o.TestFunc), formatFuncRef(o.SourceFunc), formatSourcePos(pass.Fset, o.SourceFunc),
+				formatTestRef(o.Previous), formatTestRef(o.Next), o.File)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package testalign
+
+import (
+	"go/token"
+	"testing"
+)
+
+func TestFindCrossFileOverlaps(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "Get", ReceiverType: "Store", Pos: token.Pos(10)},
+		{Name: "Put", ReceiverType: "Store", Pos: token.Pos(20)},
+		{Name: "Delete", ReceiverType: "Store", Pos: token.Pos(30)},
+		{Name: "compact", ReceiverType: "Store", Pos: token.Pos(40)},
+	}
+
+	match := func(name, file string, sf int) MatchResult {
+		return MatchResult{TestFunc: TestFunc{Name: name, FileName: file}, SourceFunc: &sourceFuncs[sf]}
+	}
+
+	tests := []struct {
+		name    string
+		matches []MatchResult
+		want    []string // 報告されるテスト関数名と範囲が重なるファイル
+	}{
+		{
+			name: "ファイルごとに連続した範囲",
+			matches: []MatchResult{
+				match("TestStore_Get", "store_test.go", 0),
+				match("TestStore_Put", "store_test.go", 1),
+				match("TestStore_Delete", "store_internal_test.go", 2),
+				match("TestStore_compact", "store_internal_test.go", 3),
+			},
+		},
+		{
+			name: "別のファイルの範囲の内側",
+			matches: []MatchResult{
+				match("TestStore_Put", "store_internal_test.go", 1),
+				match("TestStore_Get", "store_test.go", 0),
+				match("TestStore_compact", "store_test.go", 3),
+			},
+			want: []string{"TestStore_Put store_test.go"},
+		},
+		{
+			name: "範囲が交差する",
+			matches: []MatchResult{
+				match("TestStore_Put", "a_test.go", 1),
+				match("TestStore_compact", "a_test.go", 3),
+				match("TestStore_Get", "b_test.go", 0),
+				match("TestStore_Delete", "b_test.go", 2),
+			},
+			want: []string{"TestStore_Put b_test.go", "TestStore_Delete a_test.go"},
+		},
+		{
+			name: "種類が異なるテストは比べない",
+			matches: []MatchResult{
+				match("BenchmarkStore_Put", "store_bench_test.go", 1),
+				match("TestStore_Get", "store_test.go", 0),
+				match("TestStore_compact", "store_test.go", 3),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, o := range FindCrossFileOverlaps(tt.matches, sourceFuncs) {
+				got = append(got, o.TestFunc.Name+" "+o.File)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("報告数: got %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("[%d]: got %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
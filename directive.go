@@ -0,0 +1,164 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+)
+
+// 抑制ディレクティブ
+const (
+	ignoreDirective     = "//testalign:ignore"      // テスト関数単位の抑制
+	ignoreFileDirective = "//testalign:ignore-file" // テストファイル単位の抑制
+)
+
+// Directive はテストファイル中の抑制ディレクティブを表す。
+type Directive struct {
+	Pos       token.Pos // コメントの位置
+	End       token.Pos // コメントの終了位置
+	FileLevel bool      // "//testalign:ignore-file" の場合true
+	Reason    string    // ディレクティブに続く理由（省略可）
+	Target    string    // 対象の関数名（ファイル単位、または関数に付いていない場合は空）
+}
+
+// Directives はテストファイル中の抑制ディレクティブの集合を表す。
+type Directives struct {
+	File  *Directive               // ファイル単位の抑制（なければnil）
+	Funcs map[token.Pos]*Directive // 関数の宣言位置からディレクティブへのマッピング
+	Stray []*Directive             // 関数にもファイル先頭にも付いていないディレクティブ
+}
+
+// ParseDirectives はテストファイルから抑制ディレクティブを抽出する。
+//
+// "//testalign:ignore" はテスト関数のdocコメント、または宣言と同じ行に記述する。
+// "//testalign:ignore-file" は最初の宣言より前に記述する。
+// どちらもディレクティブの後に空白を挟んで理由を記述できる。
+func ParseDirectives(file *ast.File, fset *token.FileSet) Directives {
+	ds := Directives{Funcs: make(map[token.Pos]*Directive)}
+
+	// 関数のdocコメントと宣言行に付いたディレクティブ
+	attached := make(map[*ast.Comment]bool)
+	for _, d := range file.Decls {
+		decl, ok := d.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		line := fset.Position(decl.Pos()).Line
+		for _, group := range file.Comments {
+			isDoc := group == decl.Doc
+			if !isDoc && fset.Position(group.Pos()).Line != line {
+				continue
+			}
+
+			for _, c := range group.List {
+				if reason, ok := parseDirective(c.Text, ignoreDirective); ok {
+					ds.Funcs[decl.Pos()] = &Directive{Pos: c.Pos(), End: c.End(), Reason: reason, Target: decl.Name.Name}
+					attached[c] = true
+				}
+			}
+		}
+	}
+
+	firstDecl := firstDeclPos(file)
+
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if reason, ok := parseDirective(c.Text, ignoreFileDirective); ok {
+				d := &Directive{Pos: c.Pos(), End: c.End(), FileLevel: true, Reason: reason}
+				if c.Pos() < firstDecl && ds.File == nil {
+					ds.File = d
+				} else {
+					ds.Stray = append(ds.Stray, d)
+				}
+
+				continue
+			}
+
+			if reason, ok := parseDirective(c.Text, ignoreDirective); ok && !attached[c] {
+				ds.Stray = append(ds.Stray, &Directive{Pos: c.Pos(), End: c.End(), Reason: reason})
+			}
+		}
+	}
+
+	return ds
+}
+
+// firstDeclPos はファイルの最初の宣言の位置を返す。宣言がない場合はファイルの終端を返す。
+func firstDeclPos(file *ast.File) token.Pos {
+	if len(file.Decls) > 0 {
+		return file.Decls[0].Pos()
+	}
+
+	return file.End()
+}
+
+// Ignored はテスト関数が関数単位のディレクティブで抑制されているか判定する。
+func (ds Directives) Ignored(tf TestFunc) bool {
+	_, ok := ds.Funcs[tf.Pos]
+
+	return ok
+}
+
+// UnusedDirectives は抑制している違反がない（不要になった）ディレクティブを位置順に返す。
+//
+// ファイル単位のディレクティブは、抑制しなくても順序違反がない場合に不要とみなす。
+// 関数単位のディレクティブは、その関数の抑制を外しても違反数が増えない場合に不要とみなす。
+// 関数にもファイル先頭にも付いていないディレクティブは常に不要とみなす。
+func UnusedDirectives(matches []MatchResult, sourceFuncs []SourceFunc, ds Directives) []*Directive {
+	return unusedDirectives(matches, sourceFuncs, ds, KindOrder{})
+}
+
+// unusedDirectives は種類の並べ方kindsに従った順序違反をもとに不要なディレクティブを返す。
+func unusedDirectives(matches []MatchResult, sourceFuncs []SourceFunc, ds Directives, kinds KindOrder) []*Directive {
+	var unused []*Directive
+
+	if ds.File != nil && len(detectOrderViolations(matches, sourceFuncs, kinds)) == 0 {
+		unused = append(unused, ds.File)
+	}
+
+	base := len(detectOrderViolations(filterIgnored(matches, ds, token.NoPos), sourceFuncs, kinds))
+	for pos, d := range ds.Funcs {
+		if len(detectOrderViolations(filterIgnored(matches, ds, pos), sourceFuncs, kinds)) <= base {
+			unused = append(unused, d)
+		}
+	}
+
+	unused = append(unused, ds.Stray...)
+	slices.SortFunc(unused, func(a, b *Directive) int {
+		return int(a.Pos - b.Pos)
+	})
+
+	return unused
+}
+
+// parseDirective はコメントがディレクティブnameかどうかを判定し、続く理由を返す。
+func parseDirective(text, name string) (string, bool) {
+	rest, ok := strings.CutPrefix(text, name)
+	if !ok {
+		return "", false
+	}
+
+	// "//testalign:ignore" と "//testalign:ignore-file" を区別する
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return "", false
+	}
+
+	return strings.TrimSpace(rest), true
+}
+
+// filterIgnored は抑制されたテスト関数を除いたマッチ結果を返す。
+// exceptに指定したテスト関数は抑制されていても残す。
+func filterIgnored(matches []MatchResult, ds Directives, except token.Pos) []MatchResult {
+	filtered := make([]MatchResult, 0, len(matches))
+	for _, m := range matches {
+		if ds.Ignored(m.TestFunc) && m.TestFunc.Pos != except {
+			continue
+		}
+
+		filtered = append(filtered, m)
+	}
+
+	return filtered
+}
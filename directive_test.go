@@ -0,0 +1,108 @@
+package testalign
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestParseDirectives(t *testing.T) {
+	src := `//testalign:ignore-file reason here
+
+package example
+
+import "testing"
+
+// TestA はdocコメント付き。
+//
+//testalign:ignore order matters
+func TestA(t *testing.T) {}
+
+func TestB(t *testing.T) {} //testalign:ignore
+
+//testalign:ignored-typo
+func TestC(t *testing.T) {}
+
+//testalign:ignore
+var _ = 0
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example_test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("パース失敗: %v", err)
+	}
+
+	ds := ParseDirectives(file, fset)
+
+	if ds.File == nil || ds.File.Reason != "reason here" {
+		t.Errorf("File: 不正な値 %+v", ds.File)
+	}
+
+	funcs := ExtractTestFuncs(file, fset)
+	wants := map[string]string{"TestA": "order matters", "TestB": ""}
+	for _, tf := range funcs {
+		d, ok := ds.Funcs[tf.Pos]
+		want, wantOK := wants[tf.Name]
+		if ok != wantOK {
+			t.Errorf("%s: ディレクティブ有無 got %v, want %v", tf.Name, ok, wantOK)
+			continue
+		}
+		if ok && (d.Reason != want || d.Target != tf.Name) {
+			t.Errorf("%s: got reason=%q target=%q, want reason=%q", tf.Name, d.Reason, d.Target, want)
+		}
+	}
+
+	if len(ds.Stray) != 1 {
+		t.Errorf("Stray数: got %d, want 1", len(ds.Stray))
+	}
+}
+
+func TestUnusedDirectives(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "A", Pos: token.Pos(10)},
+		{Name: "B", Pos: token.Pos(20)},
+		{Name: "C", Pos: token.Pos(30)},
+	}
+	// C, A, B の順。Cの抑制は有効、Bの抑制は不要
+	matches := []MatchResult{
+		{TestFunc: TestFunc{Name: "TestC", Pos: token.Pos(100)}, SourceFunc: &sourceFuncs[2]},
+		{TestFunc: TestFunc{Name: "TestA", Pos: token.Pos(200)}, SourceFunc: &sourceFuncs[0]},
+		{TestFunc: TestFunc{Name: "TestB", Pos: token.Pos(300)}, SourceFunc: &sourceFuncs[1]},
+	}
+	ds := Directives{
+		Funcs: map[token.Pos]*Directive{
+			token.Pos(100): {Pos: token.Pos(90), Target: "TestC"},
+			token.Pos(300): {Pos: token.Pos(290), Target: "TestB"},
+		},
+	}
+
+	unused := UnusedDirectives(matches, sourceFuncs, ds)
+
+	if len(unused) != 1 {
+		t.Fatalf("不要なディレクティブ数: got %d, want 1", len(unused))
+	}
+	if unused[0].Target != "TestB" {
+		t.Errorf("Target: got %q, want %q", unused[0].Target, "TestB")
+	}
+}
+
+func TestUnusedDirectives_File(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "A", Pos: token.Pos(10)},
+		{Name: "B", Pos: token.Pos(20)},
+	}
+	matches := []MatchResult{
+		{TestFunc: TestFunc{Name: "TestA", Pos: token.Pos(100)}, SourceFunc: &sourceFuncs[0]},
+		{TestFunc: TestFunc{Name: "TestB", Pos: token.Pos(200)}, SourceFunc: &sourceFuncs[1]},
+	}
+	ds := Directives{
+		File:  &Directive{Pos: token.Pos(1), FileLevel: true},
+		Funcs: map[token.Pos]*Directive{},
+	}
+
+	unused := UnusedDirectives(matches, sourceFuncs, ds)
+
+	if len(unused) != 1 || !unused[0].FileLevel {
+		t.Errorf("ファイル単位のディレクティブが不要と判定されない: %+v", unused)
+	}
+}
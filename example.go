@@ -0,0 +1,91 @@
+package testalign
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// isExample はテスト関数がExample関数か判定する。
+func (tf TestFunc) isExample() bool {
+	if tf.Parent != "" || tf.Suite != "" {
+		return false
+	}
+
+	if tf.Prefix != "" {
+		return tf.Prefix == "Example"
+	}
+
+	return strings.HasPrefix(tf.Name, "Example")
+}
+
+// matchExample はgo docの命名規則に従ってExample関数に対応するソース関数を返す。
+//
+//	Example, Example_suffix         パッケージのExample（対応なし）
+//	ExampleF, ExampleF_suffix       関数F
+//	ExampleT, ExampleT_suffix       型T
+//	ExampleT_M, ExampleT_M_suffix   型TのメソッドM
+//
+// suffixは小文字で始まる必要がある。go/docと同様に、名前全体から順に末尾の "_suffix" を
+// 取り除きながら識別子を探すため、小文字のsuffixが非公開関数と誤認されることはない。
+// 命名規則（Naming）の設定には依存しない。
+func matchExample(tf TestFunc, sourceFuncs []SourceFunc) *SourceFunc {
+	ids := make(map[string]*SourceFunc)
+	for i := range sourceFuncs {
+		ids[exampleID(sourceFuncs[i])] = &sourceFuncs[i]
+	}
+
+	name := tf.TargetName()
+	for i := len(name); i >= 0; i = strings.LastIndexByte(name[:i], '_') {
+		prefix, ok := splitExampleName(name, i)
+		if !ok {
+			continue
+		}
+
+		// パッケージのExample
+		if prefix == "" {
+			return nil
+		}
+
+		if sf := ids[prefix]; sf != nil {
+			return sf
+		}
+	}
+
+	return nil
+}
+
+// isPackageExample はExample関数名（"Example" を除いた部分）がパッケージのExampleか判定する。
+func isPackageExample(name string) bool {
+	return name == "" || (name[0] == '_' && isExampleSuffix(name[1:]))
+}
+
+// exampleID はソース関数をExample関数名で参照する識別子（"F" または "T_M"）を返す。
+func exampleID(sf SourceFunc) string {
+	if sf.ReceiverType != "" {
+		return sf.ReceiverType + "_" + sf.Name
+	}
+
+	return sf.Name
+}
+
+// splitExampleName はExample関数名（"Example" を除いた部分）を位置iで識別子とsuffixに分割し、識別子を返す。
+// i == len(s) の場合はsuffixなしとみなす。suffixが小文字で始まらない場合はokがfalse。
+func splitExampleName(s string, i int) (prefix string, ok bool) {
+	if i == len(s) {
+		return s, true
+	}
+
+	if i == len(s)-1 {
+		return "", false
+	}
+
+	return s[:i], isExampleSuffix(s[i+1:])
+}
+
+// isExampleSuffix はsuffixが小文字で始まるか判定する。
+func isExampleSuffix(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+
+	return size > 0 && unicode.IsLower(r)
+}
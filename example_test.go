@@ -0,0 +1,77 @@
+package testalign
+
+import "testing"
+
+func TestTestFunc_isExample(t *testing.T) {
+	tests := []struct {
+		tf   TestFunc
+		want bool
+	}{
+		{TestFunc{Name: "ExampleService"}, true},
+		{TestFunc{Name: "ExampleService", Prefix: "Example"}, true},
+		{TestFunc{Name: "TestService"}, false},
+		{TestFunc{Name: "Example", Parent: "TestService"}, false},
+		{TestFunc{Name: "ExampleCreate", Suite: "ServiceSuite"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.tf.isExample(); got != tt.want {
+			t.Errorf("%+v: got %v, want %v", tt.tf, got, tt.want)
+		}
+	}
+}
+
+func TestMatchExample(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "New"},
+		{Name: "Create", ReceiverType: "Service"},
+		{Name: "suffix"},
+		{Name: "Create_all", ReceiverType: "Service"},
+		{Name: "Service", Kind: DeclType},
+	}
+
+	tests := []struct {
+		test string
+		want *SourceFunc
+	}{
+		{"ExampleNew", &sourceFuncs[0]},
+		{"ExampleNew_withOptions", &sourceFuncs[0]},
+		{"ExampleService_Create", &sourceFuncs[1]},
+		{"ExampleService_Create_retry", &sourceFuncs[1]},
+		// suffixが小文字で始まらない場合は対応なし
+		{"ExampleService_Create_Retry", nil},
+		// 識別子全体が一致する場合はsuffixとみなさない
+		{"ExampleService_Create_all", &sourceFuncs[3]},
+		{"ExampleService", &sourceFuncs[4]},
+		{"ExampleService_basic", &sourceFuncs[4]},
+		// パッケージのExample
+		{"Example", nil},
+		{"Example_suffix", nil},
+	}
+
+	for _, tt := range tests {
+		got := matchExample(TestFunc{Name: tt.test, Prefix: "Example"}, sourceFuncs)
+		if got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.test, got, tt.want)
+		}
+	}
+}
+
+func TestIsPackageExample(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"", true},
+		{"_suffix", true},
+		{"_Suffix", false},
+		{"_", false},
+		{"Service", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPackageExample(tt.name); got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
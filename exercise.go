@@ -0,0 +1,182 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ExerciseConfig はexerciseチェックの設定を表す。
+type ExerciseConfig struct {
+	Helpers bool `yaml:"helpers"` // テストファイルで宣言されたヘルパー関数の本体も参照先として調べる
+}
+
+// Unexercised は対応するソース関数を一度も参照しないテスト関数を表す。
+type Unexercised struct {
+	TestFunc   TestFunc
+	SourceFunc SourceFunc // テスト名が示すソース関数
+	MostCalled string     // テスト関数が最も多く呼び出すパッケージの関数（例: "Service.Update"）。なければ空
+}
+
+// FindUnexercisedTests は型情報を使ってテスト関数の本体を調べ、テスト名が示すソース関数を
+// 一度も参照しないテスト関数を返す（コピー&ペーストで名前だけが残ったテストなど）。
+// 呼び出しに限らず、メソッド値などとしての参照も参照とみなす。
+//
+// testFilesはパッケージのテストファイル、sourcePkgPathはテスト対象のパッケージのパス。
+// followHelpersがtrueの場合は、テストファイルで宣言されたヘルパー関数の本体もたどる。
+// 関数・メソッド以外（型・定数・変数）に対応するテスト関数と、t.Runサブテストは対象外とする。
+func FindUnexercisedTests(fset *token.FileSet, testFiles []*ast.File, info *types.Info, matches []MatchResult, sourcePkgPath string, followHelpers bool) []Unexercised {
+	decls := make(map[token.Pos]*ast.FuncDecl)
+	helpers := make(map[*types.Func]*ast.FuncDecl)
+
+	for _, file := range testFiles {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+
+			decls[funcDecl.Pos()] = funcDecl
+			if fn, ok := info.Defs[funcDecl.Name].(*types.Func); ok {
+				helpers[fn] = funcDecl
+			}
+		}
+	}
+
+	// テスト対象のパッケージのテストファイル以外で宣言された関数か
+	isSource := func(fn *types.Func) bool {
+		return fn.Pkg() != nil && strings.TrimSuffix(fn.Pkg().Path(), "_test") == sourcePkgPath &&
+			!IsTestFile(filepath.Base(fset.Position(fn.Pos()).Filename))
+	}
+
+	var unexercised []Unexercised
+
+	for _, m := range matches {
+		if m.SourceFunc == nil || m.SourceFunc.Kind != DeclFunc || m.TestFunc.Parent != "" {
+			continue
+		}
+
+		decl := decls[m.TestFunc.Pos]
+		if decl == nil {
+			continue
+		}
+
+		u := &usage{info: info, calls: make(map[*types.Func]int), visited: make(map[*ast.FuncDecl]bool)}
+		if followHelpers {
+			u.helpers = helpers
+		}
+		u.walk(decl)
+
+		sf := *m.SourceFunc
+		if slices.ContainsFunc(u.refs, func(fn *types.Func) bool { return isSource(fn) && refersToSource(fn, sf) }) {
+			continue
+		}
+
+		var mostCalled *types.Func
+		for _, fn := range u.order {
+			if isSource(fn) && (mostCalled == nil || u.calls[fn] > u.calls[mostCalled]) {
+				mostCalled = fn
+			}
+		}
+
+		result := Unexercised{TestFunc: m.TestFunc, SourceFunc: sf}
+		if mostCalled != nil {
+			result.MostCalled = formatFuncRef(SourceFunc{ReceiverType: receiverTypeName(mostCalled), Name: mostCalled.Name()})
+		}
+
+		unexercised = append(unexercised, result)
+	}
+
+	return unexercised
+}
+
+// usage はテスト関数の本体（とたどったヘルパー関数）で参照・呼び出しされた関数を集める。
+type usage struct {
+	info    *types.Info
+	helpers map[*types.Func]*ast.FuncDecl // たどるヘルパー関数（nilの場合はたどらない）
+	visited map[*ast.FuncDecl]bool
+
+	refs  []*types.Func       // 参照された関数
+	calls map[*types.Func]int // 関数ごとの静的な呼び出し回数
+	order []*types.Func       // 最初に呼び出された順の関数
+}
+
+// walk は関数宣言の本体を調べる。
+func (u *usage) walk(decl *ast.FuncDecl) {
+	if u.visited[decl] {
+		return
+	}
+	u.visited[decl] = true
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if fn := typeutil.StaticCallee(u.info, n); fn != nil {
+				fn = fn.Origin()
+				if u.calls[fn] == 0 {
+					u.order = append(u.order, fn)
+				}
+				u.calls[fn]++
+			}
+		case *ast.Ident:
+			fn, ok := u.info.Uses[n].(*types.Func)
+			if !ok {
+				break
+			}
+
+			// ジェネリックなヘルパーはインスタンス化された関数として参照されるため、元の宣言で引く
+			fn = fn.Origin()
+			u.refs = append(u.refs, fn)
+			if helper := u.helpers[fn]; helper != nil {
+				u.walk(helper)
+			}
+		}
+
+		return true
+	})
+}
+
+// refersToSource は関数fnがソース関数sfの宣言か判定する。
+// レシーバー型はエイリアスを解決した名前で、ソース関数の別名（AltReceivers）とも比べる。
+// resolve: names ではソース関数のレシーバー型は宣言に書かれた名前（エイリアスなど）のままなので、
+// fnのパッケージのスコープで解決した型とも比べる。
+func refersToSource(fn *types.Func, sf SourceFunc) bool {
+	if fn.Name() != sf.Name {
+		return false
+	}
+
+	recv := receiverTypeName(fn)
+	if recv == sf.ReceiverType || (recv != "" && slices.Contains(sf.AltReceivers, recv)) {
+		return true
+	}
+
+	if recv == "" || sf.ReceiverType == "" || fn.Pkg() == nil {
+		return false
+	}
+
+	tn, ok := fn.Pkg().Scope().Lookup(sf.ReceiverType).(*types.TypeName)
+
+	return ok && namedTypeName(tn.Type()) == recv
+}
+
+// checkExercise はテスト名が示すソース関数を参照しないテスト関数を報告する。
+func checkExercise(pass *analysis.Pass, cfg *Config, testFiles []*ast.File, matches []MatchResult) {
+	sourcePkgPath := strings.TrimSuffix(pass.Pkg.Path(), "_test")
+
+	for _, u := range FindUnexercisedTests(pass.Fset, testFiles, pass.TypesInfo, matches, sourcePkgPath, cfg.Exercise.Helpers) {
+		if u.MostCalled == "" {
+			pass.Reportf(u.TestFunc.Pos, "%s never references %s (%s)",
+				formatTestRef(u.TestFunc), formatFuncRef(u.SourceFunc), formatSourcePos(pass.Fset, u.SourceFunc))
+			continue
+		}
+
+		pass.Reportf(u.TestFunc.Pos, "%s never references %s (%s); it calls %s most often",
+			formatTestRef(u.TestFunc), formatFuncRef(u.SourceFunc), formatSourcePos(pass.Fset, u.SourceFunc), u.MostCalled)
+	}
+}
@@ -0,0 +1,87 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestFindUnexercisedTests(t *testing.T) {
+	sources := map[string]string{
+		"service.go": `package example
+
+type Service struct{}
+
+func (s *Service) Create() {}
+
+func (s *Service) Update() {}
+`,
+		"service_test.go": `package example
+
+type T struct{}
+
+func TestService_Create(t *T) {
+	s := &Service{}
+	s.Update()
+	s.Update()
+}
+
+func TestService_Update(t *T) {
+	update(&Service{})
+}
+
+func update(s *Service) { s.Update() }
+`,
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range []string{"service.go", "service_test.go"} {
+		file, err := parser.ParseFile(fset, name, sources[name], parser.ParseComments)
+		if err != nil {
+			t.Fatalf("パース失敗: %v", err)
+		}
+		files = append(files, file)
+	}
+
+	info := &types.Info{
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	if _, err := new(types.Config).Check("example", fset, files, info); err != nil {
+		t.Fatalf("型チェック失敗: %v", err)
+	}
+
+	sourceFuncs := ExtractSourceFuncs(files[0], fset)
+	matches := matchTestFuncs(ExtractTestFuncs(files[1], fset), sourceFuncs, NamingMatcher{Naming: defaultNaming})
+
+	tests := []struct {
+		followHelpers bool
+		want          []string // 報告されるテスト関数名とMostCalled
+	}{
+		{false, []string{"TestService_Create Service.Update", "TestService_Update "}},
+		{true, []string{"TestService_Create Service.Update"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, u := range FindUnexercisedTests(fset, files[1:], info, matches, "example", tt.followHelpers) {
+			got = append(got, u.TestFunc.Name+" "+u.MostCalled)
+		}
+
+		if len(got) != len(tt.want) {
+			t.Errorf("followHelpers=%v: got %q, want %q", tt.followHelpers, got, tt.want)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("followHelpers=%v [%d]: got %q, want %q", tt.followHelpers, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
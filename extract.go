@@ -10,13 +10,18 @@ import (
 // テスト関数のプレフィックス一覧
 var testPrefixes = []string{"Test", "Benchmark", "Fuzz", "Example"}
 
-// ExtractSourceFuncs はASTファイルからソース関数/メソッドを抽出する。
-// init関数は除外される。
+// ExtractSourceFuncs はASTファイルからソース関数/メソッドと、型・定数・変数の宣言を宣言順に抽出する。
+// init関数とブランク識別子（_）は除外される。
 func ExtractSourceFuncs(file *ast.File, fset *token.FileSet) []SourceFunc {
 	fileName := filepath.Base(fset.Position(file.Pos()).Filename)
 	var funcs []SourceFunc
 
 	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok {
+			funcs = append(funcs, extractGenDecl(genDecl, fset, fileName)...)
+			continue
+		}
+
 		funcDecl, ok := decl.(*ast.FuncDecl)
 		if !ok {
 			continue
@@ -31,6 +36,7 @@ func ExtractSourceFuncs(file *ast.File, fset *token.FileSet) []SourceFunc {
 			Name:     funcDecl.Name.Name,
 			Pos:      funcDecl.Pos(),
 			FileName: fileName,
+			Lines:    fset.Position(funcDecl.End()).Line - fset.Position(funcDecl.Pos()).Line + 1,
 		}
 
 		// レシーバー型を取得
@@ -44,9 +50,62 @@ func ExtractSourceFuncs(file *ast.File, fset *token.FileSet) []SourceFunc {
 	return funcs
 }
 
+// extractGenDecl は型・定数・変数の宣言から名前ごとのSourceFuncを抽出する。
+// グループ化された宣言（var ( ... )）は仕様ごと、複数の名前を持つ仕様は名前ごとに分ける。
+func extractGenDecl(decl *ast.GenDecl, fset *token.FileSet, fileName string) []SourceFunc {
+	var kind DeclKind
+	switch decl.Tok {
+	case token.TYPE:
+		kind = DeclType
+	case token.CONST:
+		kind = DeclConst
+	case token.VAR:
+		kind = DeclVar
+	default:
+		return nil
+	}
+
+	var funcs []SourceFunc
+	add := func(name *ast.Ident, spec ast.Spec) {
+		if name.Name == "_" {
+			return
+		}
+
+		funcs = append(funcs, SourceFunc{
+			Name:     name.Name,
+			Pos:      name.Pos(),
+			FileName: fileName,
+			Lines:    fset.Position(spec.End()).Line - fset.Position(spec.Pos()).Line + 1,
+			Kind:     kind,
+		})
+	}
+
+	for _, spec := range decl.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			add(spec.Name, spec)
+		case *ast.ValueSpec:
+			for _, name := range spec.Names {
+				add(name, spec)
+			}
+		}
+	}
+
+	return funcs
+}
+
 // ExtractTestFuncs はASTファイルからテスト関数を抽出する。
-// Test*/Benchmark*/Fuzz*/Example* プレフィックスの関数のみ抽出する。
+// Test*/Benchmark*/Fuzz*/Example* プレフィックスの関数と、
+// ファイル内で宣言されたtestifyスイートの Test* メソッドを抽出する。
 func ExtractTestFuncs(file *ast.File, fset *token.FileSet) []TestFunc {
+	suites := findSuiteTypes([]*ast.File{file}, SuiteConfig{Suffixes: defaultSuiteSuffixes})
+
+	return extractTestFuncs(file, fset, testPrefixes, suites)
+}
+
+// extractTestFuncs は指定されたプレフィックスを持つテスト関数を抽出する。
+// suitesはスイート型名からテスト対象の型名へのマッピングで、スイートのメソッドも抽出対象にする。
+func extractTestFuncs(file *ast.File, fset *token.FileSet, prefixes []string, suites map[string]string) []TestFunc {
 	fileName := filepath.Base(fset.Position(file.Pos()).Filename)
 	var funcs []TestFunc
 
@@ -56,17 +115,23 @@ func ExtractTestFuncs(file *ast.File, fset *token.FileSet) []TestFunc {
 			continue
 		}
 
-		// メソッドは対象外
+		// メソッドはtestifyスイートの Test* メソッドのみ対象
 		if funcDecl.Recv != nil {
+			if tf, ok := suiteTestFunc(funcDecl, fileName, suites); ok {
+				funcs = append(funcs, tf)
+			}
+
 			continue
 		}
 
-		if !isTestFunc(funcDecl.Name.Name) {
+		prefix, ok := testPrefixOf(funcDecl.Name.Name, prefixes)
+		if !ok {
 			continue
 		}
 
 		funcs = append(funcs, TestFunc{
 			Name:     funcDecl.Name.Name,
+			Prefix:   prefix,
 			Pos:      funcDecl.Pos(),
 			FileName: fileName,
 		})
@@ -75,6 +140,34 @@ func ExtractTestFuncs(file *ast.File, fset *token.FileSet) []TestFunc {
 	return funcs
 }
 
+// suiteTestFunc はメソッドがtestifyスイートのテストメソッドであればTestFuncを返す。
+// testifyは引数のない Test* メソッドをテストとして実行する。
+func suiteTestFunc(decl *ast.FuncDecl, fileName string, suites map[string]string) (TestFunc, bool) {
+	if len(decl.Recv.List) == 0 || decl.Type.Params.NumFields() != 0 {
+		return TestFunc{}, false
+	}
+
+	suite := extractReceiverType(decl.Recv.List[0].Type)
+	target, ok := suites[suite]
+	if !ok {
+		return TestFunc{}, false
+	}
+
+	prefix, ok := testPrefixOf(decl.Name.Name, []string{"Test"})
+	if !ok {
+		return TestFunc{}, false
+	}
+
+	return TestFunc{
+		Name:       decl.Name.Name,
+		Prefix:     prefix,
+		Pos:        decl.Pos(),
+		FileName:   fileName,
+		Suite:      suite,
+		TargetType: target,
+	}, true
+}
+
 // IsTestFile はファイル名がテストファイルかどうかを判定する。
 func IsTestFile(filename string) bool {
 	return strings.HasSuffix(filename, "_test.go")
@@ -88,21 +181,32 @@ func SourceFileForTest(testFile string) string {
 
 // isTestFunc はテスト関数プレフィックスを持つか判定する。
 func isTestFunc(name string) bool {
-	for _, prefix := range testPrefixes {
-		if strings.HasPrefix(name, prefix) {
-			rest := name[len(prefix):]
-			// プレフィックスだけの場合（例: "Test"）も有効
-			if rest == "" {
-				return true
-			}
-			// プレフィックスの後はアンダースコアまたは大文字で始まる必要がある
-			if rest[0] == '_' || (rest[0] >= 'A' && rest[0] <= 'Z') {
-				return true
-			}
+	_, ok := testPrefixOf(name, testPrefixes)
+
+	return ok
+}
+
+// testPrefixOf はnameが持つテスト関数プレフィックスを返す。
+// 複数のプレフィックスに一致する場合は最も長いものを返す。
+func testPrefixOf(name string, prefixes []string) (string, bool) {
+	best := ""
+	found := false
+
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(name, prefix) || (found && len(prefix) <= len(best)) {
+			continue
+		}
+
+		rest := name[len(prefix):]
+		// プレフィックスだけの場合（例: "Test"）も有効
+		// プレフィックスの後はアンダースコアまたは大文字で始まる必要がある
+		if rest == "" || rest[0] == '_' || (rest[0] >= 'A' && rest[0] <= 'Z') {
+			best = prefix
+			found = true
 		}
 	}
 
-	return false
+	return best, found
 }
 
 // extractReceiverType はレシーバーの型表現から型名を取得する。
@@ -111,6 +215,9 @@ func extractReceiverType(expr ast.Expr) string {
 	switch t := expr.(type) {
 	case *ast.StarExpr:
 		return extractReceiverType(t.X)
+	case *ast.ParenExpr:
+		// 括弧付き: (s *(Service))
+		return extractReceiverType(t.X)
 	case *ast.Ident:
 		return t.Name
 	case *ast.IndexExpr:
@@ -47,19 +47,21 @@ func (s Service) Delete() {}
 	file, fset := parseSource(t, src)
 	funcs := ExtractSourceFuncs(file, fset)
 
-	// initは除外されるので4つ
-	if got := len(funcs); got != 4 {
-		t.Fatalf("関数数: got %d, want 4", got)
+	// initは除外されるので型を含めて5つ
+	if got := len(funcs); got != 5 {
+		t.Fatalf("関数数: got %d, want 5", got)
 	}
 
 	expected := []struct {
 		name         string
 		receiverType string
+		kind         DeclKind
 	}{
-		{"PublicFunc", ""},
-		{"helper", ""},
-		{"Create", "Service"},
-		{"Delete", "Service"},
+		{"PublicFunc", "", DeclFunc},
+		{"helper", "", DeclFunc},
+		{"Service", "", DeclType},
+		{"Create", "Service", DeclFunc},
+		{"Delete", "Service", DeclFunc},
 	}
 
 	for i, e := range expected {
@@ -69,6 +71,9 @@ func (s Service) Delete() {}
 		if funcs[i].ReceiverType != e.receiverType {
 			t.Errorf("funcs[%d].ReceiverType: got %q, want %q", i, funcs[i].ReceiverType, e.receiverType)
 		}
+		if funcs[i].Kind != e.kind {
+			t.Errorf("funcs[%d].Kind: got %v, want %v", i, funcs[i].Kind, e.kind)
+		}
 	}
 }
 
@@ -86,15 +91,83 @@ func (p Pair[K, V]) Keys() []K { return nil }
 	file, fset := parseSource(t, src)
 	funcs := ExtractSourceFuncs(file, fset)
 
-	if got := len(funcs); got != 2 {
-		t.Fatalf("関数数: got %d, want 2", got)
+	if got := len(funcs); got != 4 {
+		t.Fatalf("関数数: got %d, want 4", got)
+	}
+
+	if funcs[1].ReceiverType != "Container" {
+		t.Errorf("funcs[1].ReceiverType: got %q, want %q", funcs[1].ReceiverType, "Container")
 	}
+	if funcs[3].ReceiverType != "Pair" {
+		t.Errorf("funcs[3].ReceiverType: got %q, want %q", funcs[3].ReceiverType, "Pair")
+	}
+}
+
+func TestExtractSourceFuncs_ParenReceiver(t *testing.T) {
+	src := `package example
+
+type Service struct{}
+
+func (s *(Service)) Start() {}
+
+func (Service) Stop() {}
+`
+	file, fset := parseSource(t, src)
+	funcs := ExtractSourceFuncs(file, fset)
 
-	if funcs[0].ReceiverType != "Container" {
-		t.Errorf("funcs[0].ReceiverType: got %q, want %q", funcs[0].ReceiverType, "Container")
+	if got := len(funcs); got != 3 {
+		t.Fatalf("関数数: got %d, want 3", got)
 	}
-	if funcs[1].ReceiverType != "Pair" {
-		t.Errorf("funcs[1].ReceiverType: got %q, want %q", funcs[1].ReceiverType, "Pair")
+
+	for _, sf := range funcs[1:] {
+		if sf.ReceiverType != "Service" {
+			t.Errorf("%s.ReceiverType: got %q, want %q", sf.Name, sf.ReceiverType, "Service")
+		}
+	}
+}
+
+func TestExtractSourceFuncs_ValueDecls(t *testing.T) {
+	src := `package example
+
+import "errors"
+
+const MaxSize = 10
+
+var (
+	ErrNotFound = errors.New("not found")
+	a, b        int
+	_           = a
+)
+
+type (
+	ID   int
+	Name string
+)
+`
+	file, fset := parseSource(t, src)
+	funcs := ExtractSourceFuncs(file, fset)
+
+	expected := []struct {
+		name string
+		kind DeclKind
+	}{
+		{"MaxSize", DeclConst},
+		{"ErrNotFound", DeclVar},
+		{"a", DeclVar},
+		{"b", DeclVar},
+		{"ID", DeclType},
+		{"Name", DeclType},
+	}
+
+	// importとブランク識別子は含まない
+	if got := len(funcs); got != len(expected) {
+		t.Fatalf("宣言数: got %d, want %d", got, len(expected))
+	}
+
+	for i, e := range expected {
+		if funcs[i].Name != e.name || funcs[i].Kind != e.kind {
+			t.Errorf("funcs[%d]: got %s (%v), want %s (%v)", i, funcs[i].Name, funcs[i].Kind, e.name, e.kind)
+		}
 	}
 }
 
@@ -170,6 +243,47 @@ func TestValid(t *testing.T) {}
 	}
 }
 
+func TestExtractTestFuncs_SuiteMethods(t *testing.T) {
+	src := `package example
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceSuite struct {
+	suite.Suite
+}
+
+type other struct{}
+
+func TestServiceSuite(t *testing.T) { suite.Run(t, new(ServiceSuite)) }
+
+func (s *ServiceSuite) SetupTest() {}
+
+func (s *ServiceSuite) TestCreate() {}
+
+func (s *ServiceSuite) TestWith(arg int) {}
+
+func (o other) TestIgnored() {}
+`
+	file, fset := parseTestFile(t, src)
+	funcs := ExtractTestFuncs(file, fset)
+
+	if got := len(funcs); got != 2 {
+		t.Fatalf("関数数: got %d, want 2", got)
+	}
+
+	tf := funcs[1]
+	if tf.Name != "TestCreate" || tf.Suite != "ServiceSuite" || tf.TargetType != "Service" {
+		t.Errorf("got (%q, %q, %q), want (TestCreate, ServiceSuite, Service)", tf.Name, tf.Suite, tf.TargetType)
+	}
+	if tf.TargetName() != "Create" {
+		t.Errorf("TargetName: got %q, want %q", tf.TargetName(), "Create")
+	}
+}
+
 func TestIsTestFile(t *testing.T) {
 	tests := []struct {
 		filename string
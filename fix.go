@@ -0,0 +1,158 @@
+package testalign
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// buildMoveFix は順序違反のテスト関数をdocコメントごと移動するSuggestedFixを生成する。
+// afterがtrueの場合はanchorの宣言の直後、falseの場合はanchorの宣言（docコメントを含む）の直前に移動する。
+// 宣言が見つからない場合はfalseを返す。
+func buildMoveFix(tf *token.File, file *ast.File, src []byte, target, anchor TestFunc, after bool) (analysis.SuggestedFix, bool) {
+	targetDecl := findFuncDecl(file, target.Pos)
+	anchorDecl := findFuncDecl(file, anchor.Pos)
+	if targetDecl == nil || anchorDecl == nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	text := declText(tf, src, targetDecl)
+	delStart, delEnd := declSpan(tf, src, targetDecl)
+
+	var insertPos token.Pos
+	var message string
+
+	if after {
+		end := lineEnd(src, tf.Offset(anchorDecl.End()))
+		if end == len(src) && !bytes.HasSuffix(src, []byte("\n")) {
+			text = append([]byte("\n"), text...)
+		}
+
+		insertPos = tf.Pos(end)
+		text = append([]byte("\n"), text...)
+		message = fmt.Sprintf("Move %s after %s", formatTestRef(target), formatTestRef(anchor))
+	} else {
+		insertPos = lineStart(tf, declStart(anchorDecl))
+		text = append(text, '\n')
+		message = fmt.Sprintf("Move %s before %s", formatTestRef(target), formatTestRef(anchor))
+	}
+
+	return analysis.SuggestedFix{
+		Message: message,
+		TextEdits: []analysis.TextEdit{
+			{Pos: insertPos, End: insertPos, NewText: text},
+			{Pos: tf.Pos(delStart), End: tf.Pos(delEnd)},
+		},
+	}, true
+}
+
+// buildRemoveCommentFix は行コメントを削除するSuggestedFixを生成する。
+// コメントが行全体を占める場合は行ごと削除し、それ以外はコメントと直前の空白を削除する。
+func buildRemoveCommentFix(tf *token.File, src []byte, pos, end token.Pos) analysis.SuggestedFix {
+	start := tf.Offset(pos)
+	stop := tf.Offset(end)
+	lineOffset := tf.Offset(lineStart(tf, pos))
+
+	if isBlankLine(src[lineOffset:start]) {
+		start, stop = lineOffset, lineEnd(src, stop)
+	} else {
+		for start > lineOffset && (src[start-1] == ' ' || src[start-1] == '\t') {
+			start--
+		}
+	}
+
+	return analysis.SuggestedFix{
+		Message:   "Remove unused directive",
+		TextEdits: []analysis.TextEdit{{Pos: tf.Pos(start), End: tf.Pos(stop)}},
+	}
+}
+
+// findFuncDecl はposで宣言された関数宣言を返す。
+func findFuncDecl(file *ast.File, pos token.Pos) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Pos() == pos {
+			return funcDecl
+		}
+	}
+
+	return nil
+}
+
+// declStart はdocコメントを含む宣言の開始位置を返す。
+func declStart(decl *ast.FuncDecl) token.Pos {
+	if decl.Doc != nil {
+		return decl.Doc.Pos()
+	}
+
+	return decl.Pos()
+}
+
+// declText はdocコメントと行末コメントを含む宣言のテキストを返す。
+// 範囲は開始行の行頭から終了行の改行までとなる。
+func declText(tf *token.File, src []byte, decl *ast.FuncDecl) []byte {
+	start := tf.Offset(lineStart(tf, declStart(decl)))
+	end := lineEnd(src, tf.Offset(decl.End()))
+
+	return append([]byte(nil), src[start:end]...)
+}
+
+// declSpan は宣言を削除する際のバイト範囲を返す。
+// 宣言直後の空行も削除対象に含める。直後に空行がない場合は直前の空行を含める。
+func declSpan(tf *token.File, src []byte, decl *ast.FuncDecl) (start, end int) {
+	start = tf.Offset(lineStart(tf, declStart(decl)))
+	end = lineEnd(src, tf.Offset(decl.End()))
+
+	if next := lineEnd(src, end); next > end && isBlankLine(src[end:next]) {
+		return start, next
+	}
+
+	if start > 0 {
+		if prev := prevLineStart(src, start); isBlankLine(src[prev:start]) {
+			return prev, end
+		}
+	}
+
+	return start, end
+}
+
+// lineStart はposを含む行の行頭位置を返す。
+func lineStart(tf *token.File, pos token.Pos) token.Pos {
+	return tf.LineStart(tf.Line(pos))
+}
+
+// lineEnd はoffset以降で最初の改行の直後のオフセットを返す。
+// 改行がない場合はsrcの末尾を返す。
+func lineEnd(src []byte, offset int) int {
+	for i := offset; i < len(src); i++ {
+		if src[i] == '\n' {
+			return i + 1
+		}
+	}
+
+	return len(src)
+}
+
+// prevLineStart は行頭オフセットlineOffsetの直前の行の行頭オフセットを返す。
+func prevLineStart(src []byte, lineOffset int) int {
+	for i := lineOffset - 2; i >= 0; i-- {
+		if src[i] == '\n' {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// isBlankLine は行が空白文字のみで構成されているか判定する。
+func isBlankLine(line []byte) bool {
+	for _, c := range line {
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,47 @@
+package testalign
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestDeclSpan(t *testing.T) {
+	src := `package example
+
+func TestA(t *testing.T) {}
+
+// TestB はdocコメント付き。
+func TestB(t *testing.T) {} // 行末コメント
+
+func TestC(t *testing.T) {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example_test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("パース失敗: %v", err)
+	}
+	tf := fset.File(file.Pos())
+
+	positions := make(map[string]token.Pos)
+	for _, fn := range ExtractTestFuncs(file, fset) {
+		positions[fn.Name] = fn.Pos
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		// 直後の空行を含める
+		{"TestB", "// TestB はdocコメント付き。\nfunc TestB(t *testing.T) {} // 行末コメント\n\n"},
+		// 末尾の宣言は直前の空行を含める
+		{"TestC", "\nfunc TestC(t *testing.T) {}\n"},
+	}
+
+	for _, tt := range tests {
+		start, end := declSpan(tf, []byte(src), findFuncDecl(file, positions[tt.name]))
+		if got := src[start:end]; got != tt.want {
+			t.Errorf("declSpan(%s): got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package testalign
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// flagValues はコマンドラインフラグの値。設定ファイルの内容より優先される。
+// NewAnalyzer で生成したアナライザごとに保持する。
+type flagValues struct {
+	configPath string        // 設定ファイルのパス（空の場合はパッケージのディレクトリから探索する）
+	missing    bool          // missingチェックを有効にする
+	missingCfg MissingConfig // missingチェックの設定
+	orphan     bool          // orphanチェックを有効にする
+	wrongFile  bool          // wrong_fileチェックを有効にする
+	ctors      bool          // コンストラクタを型のテストとしてまとめる
+	grouping   bool          // groupingチェックを有効にする
+	contiguity bool          // contiguityチェックを有効にする
+	layout     bool          // layoutチェックを有効にする
+	crossFile  bool          // cross_fileチェックを有効にする
+	mapping    string        // ファイル対応の方式
+	resolve    string        // テスト対象の解決方式
+	exercise   bool          // exerciseチェックを有効にする
+	helpers    bool          // exerciseチェックでヘルパー関数もたどる
+	typeOrder  string        // 型ごとのテストの並び順
+	kindLayout string        // テストの種類の並べ方
+}
+
+// register はフラグをflagsに登録する。
+func (v *flagValues) register(flags *flag.FlagSet) {
+	flags.StringVar(&v.configPath, "config", "", "`path` to the configuration file (default: discover "+ConfigFileName+" from the module root)")
+	flags.Var(&trackedFlag[bool]{p: &v.missing}, "missing", "report source functions that have no corresponding test")
+	flags.Var(&trackedFlag[bool]{p: &v.missingCfg.IncludeUnexported}, "missing.unexported", "include unexported functions and methods in the missing check")
+	flags.Var(&trackedFlag[bool]{p: &v.missingCfg.MethodsOnly}, "missing.methods", "report only methods in the missing check")
+	flags.Var(&trackedFlag[int]{p: &v.missingCfg.MinLines}, "missing.min-lines", "minimum number of `lines` of a function reported by the missing check")
+	flags.Var(&trackedFlag[bool]{p: &v.orphan}, "orphan", "report Type_Method style tests that no longer correspond to any source function")
+	flags.Var(&trackedFlag[bool]{p: &v.wrongFile}, "wrongfile", "report tests whose source function is declared in a file other than the mapped one")
+	flags.Var(&trackedFlag[bool]{p: &v.ctors}, "constructors", "order NewX/newX constructors with the declaration of type X")
+	flags.Var(&trackedFlag[bool]{p: &v.grouping}, "grouping", "report tests of a type that are interleaved with tests of another type")
+	flags.Var(&trackedFlag[bool]{p: &v.contiguity}, "contiguity", "report tests of a source function that are separated by other tests")
+	flags.Var(&trackedFlag[bool]{p: &v.layout}, "layout", "report TestMain, helpers and test-only declarations placed against the layout settings")
+	flags.Var(&trackedFlag[bool]{p: &v.crossFile}, "crossfile", "report tests that interleave with another test file mapped to the same source file")
+	flags.Var(&trackedFlag[string]{p: &v.mapping}, "mapping", "`mode` of pairing test files: "+MappingFile+" (mapped source file) or "+MappingType+" (files of the type named like the test file)")
+	flags.Var(&trackedFlag[string]{p: &v.resolve}, "resolve", "`mode` of resolving test targets: "+ResolveNames+" (declared names) or "+ResolveTypes+" (type information, reporting ambiguous test names)")
+	flags.Var(&trackedFlag[bool]{p: &v.exercise}, "exercise", "report tests that never reference the source function their name points to")
+	flags.Var(&trackedFlag[bool]{p: &v.helpers}, "exercise.helpers", "follow helper functions declared in test files in the exercise check")
+	flags.Var(&trackedFlag[string]{p: &v.kindLayout}, "kind-order.layout", "`layout` of test kinds: "+KindLayoutNone+", "+KindLayoutInterleaved+" (per function) or "+KindLayoutSegregated+" (per kind)")
+	flags.Var(&trackedFlag[string]{p: &v.typeOrder}, "grouping.type-order", "`order` of type groups: "+TypeOrderMethod+" (declaration of each method) or "+TypeOrderDeclaration+" (declaration of each type)")
+}
+
+// apply はコマンドラインで明示的に指定されたフラグで設定を上書きする。
+func (v *flagValues) apply(cfg *Config, flags *flag.FlagSet) {
+	flags.VisitAll(func(f *flag.Flag) {
+		if t, ok := f.Value.(interface{ isSet() bool }); !ok || !t.isSet() {
+			return
+		}
+
+		switch f.Name {
+		case "missing":
+			cfg.Checks[CheckMissing] = v.missing
+		case "missing.unexported":
+			cfg.Missing.IncludeUnexported = v.missingCfg.IncludeUnexported
+		case "missing.methods":
+			cfg.Missing.MethodsOnly = v.missingCfg.MethodsOnly
+		case "missing.min-lines":
+			cfg.Missing.MinLines = v.missingCfg.MinLines
+		case "orphan":
+			cfg.Checks[CheckOrphan] = v.orphan
+		case "wrongfile":
+			cfg.Checks[CheckWrongFile] = v.wrongFile
+		case "constructors":
+			cfg.Constructors = v.ctors
+		case "grouping":
+			cfg.Checks[CheckGrouping] = v.grouping
+		case "contiguity":
+			cfg.Checks[CheckContiguity] = v.contiguity
+		case "layout":
+			cfg.Checks[CheckLayout] = v.layout
+		case "crossfile":
+			cfg.Checks[CheckCrossFile] = v.crossFile
+		case "mapping":
+			cfg.Mapping = v.mapping
+		case "resolve":
+			cfg.Resolve = v.resolve
+		case "exercise":
+			cfg.Checks[CheckExercise] = v.exercise
+		case "exercise.helpers":
+			cfg.Exercise.Helpers = v.helpers
+		case "grouping.type-order":
+			cfg.Grouping.TypeOrder = v.typeOrder
+		case "kind-order.layout":
+			cfg.KindOrder.Layout = v.kindLayout
+		}
+	})
+}
+
+// trackedFlag は明示的に指定されたかどうかを記録するフラグ値。
+// ドライバはフラグ値をそのまま自身のFlagSetに登録するため、
+// Analyzer.Flags.Visit では指定の有無を判定できない。
+type trackedFlag[T bool | int | string] struct {
+	p   *T
+	set bool
+}
+
+func (f *trackedFlag[T]) String() string {
+	// flagパッケージはデフォルト値の判定のためゼロ値に対してもStringを呼び出す
+	if f == nil || f.p == nil {
+		var zero T
+		return fmt.Sprint(zero)
+	}
+
+	return fmt.Sprint(*f.p)
+}
+
+func (f *trackedFlag[T]) Set(s string) error {
+	switch p := any(f.p).(type) {
+	case *bool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		*p = v
+	case *int:
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		*p = v
+	case *string:
+		*p = s
+	}
+
+	f.set = true
+
+	return nil
+}
+
+// IsBoolFlag は値なしの指定（例: -missing）を許可する。
+func (f *trackedFlag[T]) IsBoolFlag() bool {
+	_, ok := any(f.p).(*bool)
+
+	return ok
+}
+
+func (f *trackedFlag[T]) isSet() bool {
+	return f.set
+}
@@ -2,7 +2,10 @@ module github.com/basashifx/go-testalign
 
 go 1.25.3
 
-require golang.org/x/tools v0.41.0
+require (
+	golang.org/x/tools v0.41.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	golang.org/x/mod v0.32.0 // indirect
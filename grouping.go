@@ -0,0 +1,142 @@
+package testalign
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// GroupingConfig はレシーバー型ごとのテストのまとまり（groupingチェック）と型の並び順の設定を表す。
+// TypeOrderMethod ではソース関数の宣言が型をまたいで入り組んでいると、順序検証とgroupingチェックが
+// 異なる並びを求めるため、groupingチェックは TypeOrderDeclaration と組み合わせて使う。
+type GroupingConfig struct {
+	TypeOrder string `yaml:"type_order"` // 型ごとのテストの並び順（TypeOrderMethod、TypeOrderDeclaration）
+}
+
+// 型ごとのテストの並び順。GroupingConfig.TypeOrder に指定する。
+const (
+	TypeOrderMethod      = "method"      // 型の宣言とメソッドをそれぞれの宣言位置に従って並べる（既定）
+	TypeOrderDeclaration = "declaration" // 型の宣言順に、型ごとにメソッドをまとめて並べる
+)
+
+// Interleaving は同じ型のテスト関数の間に、別の型のテスト関数が挟まっていることを表す。
+type Interleaving struct {
+	TestFunc  TestFunc // 別の型のテストの後に現れたテスト関数
+	Type      string   // TestFuncが対象とする型
+	Previous  TestFunc // 同じ型を対象とする直前のテスト関数
+	Separator TestFunc // 間に挟まった別の型のテスト関数
+	Other     string   // Separatorが対象とする型
+}
+
+// FindInterleavedTests はテスト関数の並びから、同じ型のテストが連続していない箇所を返す。
+// 型を対象としないテスト関数（パッケージ関数のテストやマッチしないもの）は判定に影響しない。
+func FindInterleavedTests(matches []MatchResult) []Interleaving {
+	var interleavings []Interleaving
+	last := make(map[string]TestFunc)
+	var prevType string
+	var prev TestFunc
+
+	for _, m := range matches {
+		if m.SourceFunc == nil {
+			continue
+		}
+
+		typ := groupKey(*m.SourceFunc)
+		if typ == "" {
+			continue
+		}
+
+		if earlier, ok := last[typ]; ok && prevType != typ {
+			interleavings = append(interleavings, Interleaving{
+				TestFunc:  m.TestFunc,
+				Type:      typ,
+				Previous:  earlier,
+				Separator: prev,
+				Other:     prevType,
+			})
+		}
+
+		last[typ] = m.TestFunc
+		prevType, prev = typ, m.TestFunc
+	}
+
+	return interleavings
+}
+
+// groupKey はソース関数が属する型名を返す。
+// メソッドはレシーバー型、型宣言はその型、コンストラクタは生成する型に属し、それ以外は空文字を返す。
+func groupKey(sf SourceFunc) string {
+	switch {
+	case sf.ReceiverType != "":
+		return sf.ReceiverType
+	case sf.Kind == DeclType:
+		return sf.Name
+	default:
+		return sf.Constructs
+	}
+}
+
+// groupByType は型の宣言の直後に、その型のメソッドとコンストラクタを宣言順にまとめた一覧を返す。
+// 型がファイル内で宣言されていないメソッドとパッケージ関数は元の位置に残す。
+func groupByType(funcs []SourceFunc) []SourceFunc {
+	declared := make(map[string]bool)
+	for _, sf := range funcs {
+		if sf.Kind == DeclType {
+			declared[sf.Name] = true
+		}
+	}
+
+	members := make(map[string][]SourceFunc)
+	var rest []SourceFunc
+
+	for _, sf := range funcs {
+		if typ := groupKey(sf); sf.Kind != DeclType && declared[typ] {
+			members[typ] = append(members[typ], sf)
+			continue
+		}
+
+		rest = append(rest, sf)
+	}
+
+	grouped := make([]SourceFunc, 0, len(funcs))
+	for _, sf := range rest {
+		grouped = append(grouped, sf)
+		if sf.Kind == DeclType {
+			grouped = append(grouped, members[sf.Name]...)
+		}
+	}
+
+	return grouped
+}
+
+// arrangeSourceFuncs は設定に従って、テスト関数の並び順の基準となるソース関数の並びを調整する。
+// filesはソースファイル名から構文木へのマッピングで、infoがnilの場合は型情報による解決と
+// コンストラクタのまとめは行わない。
+func arrangeSourceFuncs(cfg *Config, files map[string]*ast.File, info *types.Info, allSourceFuncs map[string][]SourceFunc) {
+	if cfg.Resolve == ResolveTypes && info != nil {
+		resolveTargets(files, info, allSourceFuncs)
+	}
+
+	if cfg.Constructors && info != nil {
+		markConstructors(files, info, allSourceFuncs)
+	}
+
+	if cfg.Grouping.TypeOrder == TypeOrderDeclaration {
+		for fileName, funcs := range allSourceFuncs {
+			allSourceFuncs[fileName] = groupByType(funcs)
+		}
+	}
+}
+
+// checkGrouping はテストファイル内で同じ型のテストが連続していない箇所を報告する。
+func checkGrouping(pass *analysis.Pass, matches []MatchResult) {
+	for _, il := range FindInterleavedTests(matches) {
+		pass.Report(analysis.Diagnostic{
+			Pos: il.TestFunc.Pos,
+			Message: fmt.Sprintf("%s tests %s but is separated from %s by %s, which tests %s",
+				formatTestRef(il.TestFunc), il.Type, formatTestRef(il.Previous), formatTestRef(il.Separator), il.Other),
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package testalign
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindInterleavedTests(t *testing.T) {
+	order := SourceFunc{Name: "Create", ReceiverType: "Order"}
+	orderType := SourceFunc{Name: "Order", Kind: DeclType}
+	user := SourceFunc{Name: "Get", ReceiverType: "User"}
+	newUser := SourceFunc{Name: "NewUser", Constructs: "User"}
+	open := SourceFunc{Name: "Open"}
+
+	matches := []MatchResult{
+		{TestFunc: TestFunc{Name: "TestOrder"}, SourceFunc: &orderType},
+		{TestFunc: TestFunc{Name: "TestOrder_Create"}, SourceFunc: &order},
+		{TestFunc: TestFunc{Name: "TestOpen"}, SourceFunc: &open},
+		{TestFunc: TestFunc{Name: "TestIntegration"}},
+		{TestFunc: TestFunc{Name: "TestNewUser"}, SourceFunc: &newUser},
+		{TestFunc: TestFunc{Name: "TestOrder_Create_Error"}, SourceFunc: &order},
+		{TestFunc: TestFunc{Name: "TestUser_Get"}, SourceFunc: &user},
+	}
+
+	got := FindInterleavedTests(matches)
+
+	// パッケージ関数とマッチしないテストは無視し、コンストラクタは型Userのテストとみなす
+	want := []Interleaving{
+		{TestFunc: matches[5].TestFunc, Type: "Order", Previous: matches[1].TestFunc, Separator: matches[4].TestFunc, Other: "User"},
+		{TestFunc: matches[6].TestFunc, Type: "User", Previous: matches[4].TestFunc, Separator: matches[5].TestFunc, Other: "Order"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestGroupByType(t *testing.T) {
+	funcs := []SourceFunc{
+		{Name: "User", Kind: DeclType},
+		{Name: "Order", Kind: DeclType},
+		{Name: "Create", ReceiverType: "Order"},
+		{Name: "Open"},
+		{Name: "Get", ReceiverType: "User"},
+		{Name: "NewUser", Constructs: "User"},
+		// 型がファイル内にないメソッドは元の位置に残る
+		{Name: "Close", ReceiverType: "Conn"},
+	}
+
+	var got []string
+	for _, sf := range groupByType(funcs) {
+		got = append(got, sf.Name)
+	}
+
+	want := []string{"User", "Get", "NewUser", "Order", "Create", "Open", "Close"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+package testalign
+
+import "slices"
+
+// KindOrder はテストの種類（Test、Fuzz、Benchmark、Example）の並べ方を表す。
+type KindOrder struct {
+	Layout string   `yaml:"layout"` // 並べ方（KindLayoutNone、KindLayoutInterleaved、KindLayoutSegregated）
+	Kinds  []string `yaml:"kinds"`  // 種類（テストプレフィックス）の順序
+}
+
+// 種類の並べ方。KindOrder.Layout に指定する。
+const (
+	KindLayoutNone        = "none"        // 同じソース関数のテストの種類の順序を問わない（既定）
+	KindLayoutInterleaved = "interleaved" // ソース関数ごとに、種類の順序で並べる
+	KindLayoutSegregated  = "segregated"  // 種類ごとにまとめ、その中でソース関数の宣言順に並べる
+)
+
+// defaultKindOrder は既定の種類の順序。
+var defaultKindOrder = []string{"Test", "Fuzz", "Benchmark", "Example"}
+
+// sortKey はソース関数のインデックスindexに対応するテスト関数の並び順のキーを返す。
+// nはソース関数の数。
+func (k KindOrder) sortKey(tf TestFunc, index, n int) int {
+	switch k.Layout {
+	case KindLayoutInterleaved:
+		return index*(len(k.Kinds)+1) + k.rank(tf)
+	case KindLayoutSegregated:
+		return k.rank(tf)*n + index
+	}
+
+	return index
+}
+
+// rank はテスト関数の種類の順序を返す。Kindsにない種類は最後とし、サブテストは常に0とする。
+func (k KindOrder) rank(tf TestFunc) int {
+	if tf.Parent != "" {
+		return 0
+	}
+
+	if i := slices.Index(k.Kinds, testKind(tf)); i >= 0 {
+		return i
+	}
+
+	return len(k.Kinds)
+}
+
+// testKind はテスト関数の種類（プレフィックス。例: "Test"、"Benchmark"）を返す。
+func testKind(tf TestFunc) string {
+	if tf.Prefix != "" {
+		return tf.Prefix
+	}
+
+	prefix, _ := testPrefixOf(tf.Name, testPrefixes)
+
+	return prefix
+}
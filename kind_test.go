@@ -0,0 +1,33 @@
+package testalign
+
+import "testing"
+
+func TestKindOrder_sortKey(t *testing.T) {
+	kinds := []string{"Test", "Fuzz", "Benchmark", "Example"}
+	test := TestFunc{Name: "TestParse"}
+	bench := TestFunc{Name: "BenchmarkParse", Prefix: "Benchmark"}
+
+	tests := []struct {
+		layout string
+		tf     TestFunc
+		index  int
+		want   int
+	}{
+		{KindLayoutNone, bench, 1, 1},
+		// ソース関数ごとに種類の順
+		{KindLayoutInterleaved, test, 1, 5},
+		{KindLayoutInterleaved, bench, 0, 2},
+		// 種類ごとにソース関数の順
+		{KindLayoutSegregated, test, 1, 1},
+		{KindLayoutSegregated, bench, 0, 20},
+		// サブテストは親の種類によらず0
+		{KindLayoutSegregated, TestFunc{Name: "Parse", Parent: "BenchmarkParser"}, 3, 3},
+	}
+
+	for _, tt := range tests {
+		got := KindOrder{Layout: tt.layout, Kinds: kinds}.sortKey(tt.tf, tt.index, 10)
+		if got != tt.want {
+			t.Errorf("%s %s: got %d, want %d", tt.layout, tt.tf.Name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package testalign
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// LayoutConfig はテストファイル内のテスト関数以外の宣言の配置（layoutチェック）の設定を表す。
+// DefaultConfig はすべての項目を設定する。LayoutNone の項目は検証しない（空の項目も同様）。
+type LayoutConfig struct {
+	TestMain     string `yaml:"test_main"`    // TestMainの位置（LayoutTop、LayoutBottom、LayoutNone）
+	Helpers      string `yaml:"helpers"`      // ヘルパー関数の位置（LayoutAfterCaller、LayoutEnd、LayoutNone）
+	Declarations string `yaml:"declarations"` // 型・変数・定数の位置（LayoutBeforeUse、LayoutNone）
+}
+
+// 宣言の配置。LayoutConfig の各項目に指定する。
+const (
+	LayoutTop         = "top"          // ほかのすべての関数より前（TestMain）
+	LayoutBottom      = "bottom"       // ほかのすべての関数より後（TestMain）
+	LayoutAfterCaller = "after_caller" // 最初の呼び出し元の直後（ヘルパー関数）
+	LayoutEnd         = "end"          // ヘルパー以外のすべての関数より後（ヘルパー関数）
+	LayoutBeforeUse   = "before_use"   // 最初に使用する宣言より前（型・変数・定数）
+	LayoutNone        = "none"         // 配置を検証しない
+)
+
+// LayoutIssue はテストファイル内の宣言の配置の違反を表す。
+type LayoutIssue struct {
+	Pos     token.Pos // 違反した宣言の位置
+	Message string    // 診断メッセージ
+}
+
+// FindLayoutIssues はテストファイル内のTestMain、ヘルパー関数、型・変数・定数の宣言の配置を検証し、
+// 違反を宣言順に返す。
+//
+// ヘルパー関数は、テスト関数以外の関数のうち testing.TB 型の引数を持つもの、
+// または *testing.T などの引数に対して Helper を呼び出すものとする。
+// prefixesはテスト関数のプレフィックス。宣言の参照は型情報infoで解決し、
+// 同じ名前のローカル変数などは参照とみなさない。infoがnilの場合は識別子の名前だけで判定する。
+func FindLayoutIssues(file *ast.File, info *types.Info, prefixes []string, cfg LayoutConfig) []LayoutIssue {
+	var funcs []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok {
+			funcs = append(funcs, fd)
+		}
+	}
+
+	testingName, imported := testingImportName(file)
+	helpers := make(map[*ast.FuncDecl]bool)
+	for _, fd := range funcs {
+		if _, ok := testPrefixOf(fd.Name.Name, prefixes); !ok && fd.Recv == nil && imported && isHelper(fd, testingName) {
+			helpers[fd] = true
+		}
+	}
+
+	var issues []LayoutIssue
+
+	for i, fd := range funcs {
+		switch {
+		case fd.Recv == nil && fd.Name.Name == "TestMain":
+			if issue, ok := checkTestMain(funcs, i, cfg.TestMain); ok {
+				issues = append(issues, issue)
+			}
+		case helpers[fd]:
+			if issue, ok := checkHelper(funcs, i, helpers, info, cfg.Helpers); ok {
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	if cfg.Declarations == LayoutBeforeUse {
+		issues = append(issues, checkDeclarations(file, info)...)
+	}
+
+	return issues
+}
+
+// checkTestMain はfuncs[i]のTestMainがpolicyの位置にあるか検証する。
+func checkTestMain(funcs []*ast.FuncDecl, i int, policy string) (LayoutIssue, bool) {
+	fd := funcs[i]
+
+	switch {
+	case policy == LayoutTop && i > 0:
+		return LayoutIssue{Pos: fd.Pos(), Message: fmt.Sprintf("TestMain should be placed before %s", funcName(funcs[0]))}, true
+	case policy == LayoutBottom && i < len(funcs)-1:
+		return LayoutIssue{Pos: fd.Pos(), Message: fmt.Sprintf("TestMain should be placed after %s", funcName(funcs[len(funcs)-1]))}, true
+	}
+
+	return LayoutIssue{}, false
+}
+
+// checkHelper はfuncs[i]のヘルパー関数がpolicyの位置にあるか検証する。
+//
+// LayoutAfterCaller では、最初の呼び出し元との間にヘルパー以外の関数がない場合に正しい位置とみなす。
+// ファイル内に呼び出し元がない場合は検証しない。
+// LayoutEnd では、後ろにヘルパーとTestMain以外の関数がない場合に正しい位置とみなす。
+func checkHelper(funcs []*ast.FuncDecl, i int, helpers map[*ast.FuncDecl]bool, info *types.Info, policy string) (LayoutIssue, bool) {
+	fd := funcs[i]
+
+	switch policy {
+	case LayoutAfterCaller:
+		caller := -1
+		for j, other := range funcs {
+			if j != i && references(other, fd.Name, info) {
+				caller = j
+				break
+			}
+		}
+
+		if caller < 0 {
+			return LayoutIssue{}, false
+		}
+
+		placed := caller < i
+		for j := caller + 1; placed && j < i; j++ {
+			placed = helpers[funcs[j]]
+		}
+
+		if !placed {
+			return LayoutIssue{Pos: fd.Pos(), Message: fmt.Sprintf("helper %s should be placed directly after %s, its first caller", fd.Name.Name, funcName(funcs[caller]))}, true
+		}
+	case LayoutEnd:
+		for j := len(funcs) - 1; j > i; j-- {
+			if !helpers[funcs[j]] && funcName(funcs[j]) != "TestMain" {
+				return LayoutIssue{Pos: fd.Pos(), Message: fmt.Sprintf("helper %s should be placed at the end of the file, after %s", fd.Name.Name, funcName(funcs[j]))}, true
+			}
+		}
+	}
+
+	return LayoutIssue{}, false
+}
+
+// checkDeclarations はテストファイルで宣言された型・変数・定数のうち、
+// それを参照する宣言より後にあるものを返す。
+func checkDeclarations(file *ast.File, info *types.Info) []LayoutIssue {
+	var issues []LayoutIssue
+
+	for i, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok == token.IMPORT {
+			continue
+		}
+
+		for _, name := range declaredNames(gen) {
+			for _, user := range file.Decls[:i] {
+				if references(user, name, info) {
+					issues = append(issues, LayoutIssue{Pos: name.Pos(), Message: fmt.Sprintf("%s is declared after its first use in %s", name.Name, declName(user))})
+					break
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// isHelper は関数が testing.TB 型の引数を持つか、testingの型の引数に対して Helper を呼び出すか判定する。
+// testingNameはファイルでtestingパッケージを参照する名前。
+func isHelper(fd *ast.FuncDecl, testingName string) bool {
+	params := make(map[string]bool)
+
+	for _, field := range fd.Type.Params.List {
+		typ := field.Type
+		if isSelector(typ, testingName, "TB") {
+			return true
+		}
+
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+
+		for _, name := range []string{"T", "B", "F"} {
+			if isSelector(typ, testingName, name) {
+				for _, id := range field.Names {
+					params[id.Name] = true
+				}
+			}
+		}
+	}
+
+	found := false
+	if fd.Body != nil {
+		ast.Inspect(fd.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 0 {
+				return !found
+			}
+
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if ok && sel.Sel.Name == "Helper" {
+				if id, ok := sel.X.(*ast.Ident); ok && params[id.Name] {
+					found = true
+				}
+			}
+
+			return !found
+		})
+	}
+
+	return found
+}
+
+// references は宣言が識別子nameで宣言されたものを参照しているか判定する。
+// 関数宣言の名前は参照とみなさない。
+func references(decl ast.Decl, name *ast.Ident, info *types.Info) bool {
+	fd, ok := decl.(*ast.FuncDecl)
+	if !ok {
+		return refersTo(decl, name, info)
+	}
+
+	return (fd.Recv != nil && refersTo(fd.Recv, name, info)) || refersTo(fd.Type, name, info) || (fd.Body != nil && refersTo(fd.Body, name, info))
+}
+
+// refersTo はノードが識別子nameで宣言されたものを参照しているか判定する。
+// infoに宣言のオブジェクトがある場合は、識別子の参照先のオブジェクトと比べる（ローカル変数による隠蔽を区別する）。
+// それ以外は名前で比べ、セレクタの右辺（x.name）、構造体のフィールド名、複合リテラルのキーは参照とみなさない。
+func refersTo(node ast.Node, name *ast.Ident, info *types.Info) bool {
+	var obj types.Object
+	if info != nil {
+		obj = info.Defs[name]
+	}
+
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			found = refersTo(n.X, name, info)
+			return false
+		case *ast.Field:
+			found = refersTo(n.Type, name, info)
+			return false
+		case *ast.KeyValueExpr:
+			if _, ok := n.Key.(*ast.Ident); ok {
+				found = refersTo(n.Value, name, info)
+				return false
+			}
+		case *ast.Ident:
+			if obj != nil {
+				found = info.Uses[n] == obj
+			} else {
+				found = n.Name == name.Name
+			}
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// declaredNames は型・変数・定数の宣言で宣言される名前を返す。ブランク識別子は含まない。
+func declaredNames(gen *ast.GenDecl) []*ast.Ident {
+	var names []*ast.Ident
+
+	for _, spec := range gen.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			names = append(names, spec.Name)
+		case *ast.ValueSpec:
+			for _, id := range spec.Names {
+				if id.Name != "_" {
+					names = append(names, id)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// declName は診断で宣言を示す名前を返す。型・変数・定数の宣言は最初の名前を返す。
+func declName(decl ast.Decl) string {
+	if fd, ok := decl.(*ast.FuncDecl); ok {
+		return funcName(fd)
+	}
+
+	if names := declaredNames(decl.(*ast.GenDecl)); len(names) > 0 {
+		return names[0].Name
+	}
+
+	return "_"
+}
+
+// funcName は診断で関数を示す名前を返す。メソッドは "Type.Method" の形式。
+func funcName(fd *ast.FuncDecl) string {
+	if fd.Recv != nil && len(fd.Recv.List) > 0 {
+		return extractReceiverType(fd.Recv.List[0].Type) + "." + fd.Name.Name
+	}
+
+	return fd.Name.Name
+}
+
+// testingImportName はファイルでtestingパッケージを参照する名前を返す。
+func testingImportName(file *ast.File) (string, bool) {
+	for _, spec := range file.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err == nil && p == "testing" {
+			return importName(spec), true
+		}
+	}
+
+	return "", false
+}
+
+// checkLayout はテストファイル内の宣言の配置の違反を報告する。
+func checkLayout(pass *analysis.Pass, cfg *Config, file *ast.File) {
+	for _, issue := range FindLayoutIssues(file, pass.TypesInfo, cfg.TestPrefixes, cfg.Layout) {
+		pass.Reportf(issue.Pos, "%s", issue.Message)
+	}
+}
@@ -0,0 +1,80 @@
+package testalign
+
+import (
+	"go/ast"
+	"slices"
+	"testing"
+)
+
+func TestFindLayoutIssues(t *testing.T) {
+	src := `package example
+
+import "testing"
+
+func TestA(t *testing.T) { helper(t) }
+
+func helper(t *testing.T) { t.Helper() }
+
+func TestMain(m *testing.M) {}
+
+func TestB(t *testing.T) { _ = value }
+
+var value = 1
+`
+	file, _ := parseTestFile(t, src)
+
+	tests := []struct {
+		cfg  LayoutConfig
+		want []string
+	}{
+		// 空の項目とLayoutNoneの項目は検証しない
+		{LayoutConfig{}, nil},
+		{LayoutConfig{TestMain: LayoutNone, Helpers: LayoutNone, Declarations: LayoutNone}, nil},
+		{LayoutConfig{TestMain: LayoutTop}, []string{"TestMain should be placed before TestA"}},
+		{LayoutConfig{TestMain: LayoutBottom}, []string{"TestMain should be placed after TestB"}},
+		{LayoutConfig{Helpers: LayoutAfterCaller}, nil},
+		// TestMainはヘルパーの後に置いてもよい
+		{LayoutConfig{Helpers: LayoutEnd}, []string{"helper helper should be placed at the end of the file, after TestB"}},
+		{LayoutConfig{Declarations: LayoutBeforeUse}, []string{"value is declared after its first use in TestB"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, issue := range FindLayoutIssues(file, nil, testPrefixes, tt.cfg) {
+			got = append(got, issue.Message)
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%+v: got %q, want %q", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestIsHelper(t *testing.T) {
+	src := `package example
+
+import tt "testing"
+
+func withTB(tb tt.TB) {}
+
+func callsHelper(b *tt.B) { b.Helper() }
+
+func otherHelper(b *tt.B, x interface{ Helper() }) { x.Helper() }
+
+func plain(t *tt.T) {}
+`
+	file, _ := parseTestFile(t, src)
+
+	want := map[string]bool{"withTB": true, "callsHelper": true, "otherHelper": false, "plain": false}
+
+	for _, decl := range file.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if got := isHelper(fd, "tt"); got != want[fd.Name.Name] {
+			t.Errorf("%s: got %v, want %v", fd.Name.Name, got, want[fd.Name.Name])
+		}
+	}
+}
@@ -1,17 +1,62 @@
 package testalign
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
-// MatchTestFuncs はテスト関数をソース関数にマッチングする。
+// Matcher はテスト関数に対応するソース関数を特定する。
+// 独自の命名規則を使う場合は、Matcherを実装して WithMatcher で NewAnalyzer に渡す。
+type Matcher interface {
+	// Match はsourceFuncsからテスト関数tfに対応するソース関数を探して返す。
+	// 対応するソース関数がない場合はnilを返す。戻り値はsourceFuncsの要素を指す必要がある。
+	Match(tf TestFunc, sourceFuncs []SourceFunc) *SourceFunc
+}
+
+// MatcherFunc は関数をMatcherとして使うためのアダプタ。
+type MatcherFunc func(tf TestFunc, sourceFuncs []SourceFunc) *SourceFunc
+
+// Match はf(tf, sourceFuncs)を返す。
+func (f MatcherFunc) Match(tf TestFunc, sourceFuncs []SourceFunc) *SourceFunc {
+	return f(tf, sourceFuncs)
+}
+
+// NamingMatcher は命名規則Namingに従ってマッチングする既定のMatcher。
 // マッチングルール（優先度順）:
 // 1. 完全一致: TargetName == QualifiedName
-// 2. サブテストマッチ: TargetNameがQualifiedName + "_"で始まる最長一致
-// 3. マッチなしの場合はSourceFuncがnilのMatchResultを返す
+// 2. サブテストマッチ: TargetNameがQualifiedName + シナリオの区切りで始まる最長一致
+type NamingMatcher struct {
+	Naming Naming
+}
+
+// Match はテスト関数のターゲット名に対応するソース関数を返す。
+// testifyスイートのメソッドとサブテストはテスト対象の型のメソッドと照合し、
+// Example関数はgo docの命名規則で照合する。
+func (m NamingMatcher) Match(tf TestFunc, sourceFuncs []SourceFunc) *SourceFunc {
+	if tf.isExample() {
+		return matchExample(tf, sourceFuncs)
+	}
+
+	if tf.TargetType != "" {
+		return matchTypedTest(tf, sourceFuncs, m.Naming)
+	}
+
+	return matchTestToSource(tf.TargetName(), sourceFuncs, m.Naming)
+}
+
+// MatchTestFuncs はテスト関数をソース関数にマッチングする。
+// 既定の命名規則（"Type_Method"）の NamingMatcher を使い、
+// マッチしない場合はSourceFuncがnilのMatchResultを返す。
 func MatchTestFuncs(testFuncs []TestFunc, sourceFuncs []SourceFunc) []MatchResult {
+	return matchTestFuncs(testFuncs, sourceFuncs, NamingMatcher{Naming: defaultNaming})
+}
+
+// matchTestFuncs はMatcher mでテスト関数をソース関数にマッチングする。
+func matchTestFuncs(testFuncs []TestFunc, sourceFuncs []SourceFunc, m Matcher) []MatchResult {
 	results := make([]MatchResult, 0, len(testFuncs))
 
 	for _, tf := range testFuncs {
-		matched := matchTestToSource(tf.TargetName(), sourceFuncs)
+		matched := m.Match(tf, sourceFuncs)
 		results = append(results, MatchResult{
 			TestFunc:   tf,
 			SourceFunc: matched,
@@ -22,30 +67,116 @@ func MatchTestFuncs(testFuncs []TestFunc, sourceFuncs []SourceFunc) []MatchResul
 }
 
 // matchTestToSource はテスト関数のターゲット名に対応するソース関数を探す。
-func matchTestToSource(targetName string, sourceFuncs []SourceFunc) *SourceFunc {
+func matchTestToSource(targetName string, sourceFuncs []SourceFunc, naming Naming) *SourceFunc {
 	if targetName == "" {
 		return nil
 	}
 
 	// 1. 完全一致を試行
 	for i := range sourceFuncs {
-		if sourceFuncs[i].QualifiedName() == targetName {
+		if slices.Contains(naming.matchNames(sourceFuncs[i]), targetName) {
 			return &sourceFuncs[i]
 		}
 	}
 
-	// 2. サブテストマッチ: targetNameがQualifiedName + "_"で始まる最長一致
+	// 2. サブテストマッチ: targetNameがQualifiedNameとシナリオ名に分けられる最長一致
+	// 型は完全一致のみとする（"TestService_Renew" は型Serviceのシナリオではなく、存在しないメソッドのテストとみなす）
 	var bestMatch *SourceFunc
 	bestLen := 0
 
 	for i := range sourceFuncs {
-		qname := sourceFuncs[i].QualifiedName()
-		prefix := qname + "_"
-		if strings.HasPrefix(targetName, prefix) && len(qname) > bestLen {
-			bestMatch = &sourceFuncs[i]
-			bestLen = len(qname)
+		if sourceFuncs[i].Kind == DeclType {
+			continue
+		}
+
+		for _, qname := range naming.matchNames(sourceFuncs[i]) {
+			if naming.hasScenario(targetName, qname) && len(qname) > bestLen {
+				bestMatch = &sourceFuncs[i]
+				bestLen = len(qname)
+			}
 		}
 	}
 
 	return bestMatch
 }
+
+// matchTypedTest はテスト対象の型（TargetType）が決まっているテスト関数
+// （testifyスイートのメソッド、t.Runサブテスト）に対応するソース関数を探す。
+// テスト対象の型のメソッドとして "Type_Method" 形式の修飾名で照合し、
+// パッケージにテスト対象の型のメソッドがない場合はパッケージ関数と照合する。
+func matchTypedTest(tf TestFunc, sourceFuncs []SourceFunc, naming Naming) *SourceFunc {
+	target := tf.TargetName()
+	if target == "" {
+		return nil
+	}
+
+	for _, sf := range sourceFuncs {
+		if sf.ReceiverType == tf.TargetType {
+			return matchTestToSource(naming.QualifiedName(SourceFunc{ReceiverType: tf.TargetType, Name: target}), sourceFuncs, naming)
+		}
+	}
+
+	var funcs []SourceFunc
+	for _, sf := range sourceFuncs {
+		if sf.ReceiverType == "" {
+			funcs = append(funcs, sf)
+		}
+	}
+
+	matched := matchTestToSource(target, funcs, naming)
+	if matched == nil {
+		return nil
+	}
+
+	// sourceFuncsの要素を指すポインタを返す
+	for i := range sourceFuncs {
+		if sourceFuncs[i].ReceiverType == "" && sourceFuncs[i].Pos == matched.Pos && sourceFuncs[i].Name == matched.Name {
+			return &sourceFuncs[i]
+		}
+	}
+
+	return nil
+}
+
+// matchNames はソース関数に対応するテスト名（修飾名）の候補を返す。
+// コンストラクタの場合は型のメソッドNewとしての修飾名（例: "Service_New"）も含め、
+// 別のレシーバー型名（AltReceivers）がある場合はそれぞれの修飾名も含める。
+func (n Naming) matchNames(sf SourceFunc) []string {
+	names := []string{n.QualifiedName(sf)}
+	for _, alt := range sf.AltReceivers {
+		names = append(names, n.QualifiedName(SourceFunc{ReceiverType: alt, Name: sf.Name}))
+	}
+	if sf.Constructs != "" {
+		names = append(names, n.QualifiedName(SourceFunc{ReceiverType: sf.Constructs, Name: "New"}))
+	}
+
+	return names
+}
+
+// scenarioSeparator は修飾名とシナリオ名の区切りを返す。
+// ScenarioSeparator が空の場合はSeparatorで、NamingCamel では区切りなし（空文字）とする。
+func (n Naming) scenarioSeparator() string {
+	if n.ScenarioSeparator == "" && n.Style != NamingCamel {
+		return n.Separator
+	}
+
+	return n.ScenarioSeparator
+}
+
+// hasScenario はtargetNameが修飾名qnameにシナリオ名を続けた名前か判定する。
+// シナリオの区切りは ScenarioSeparator（空の場合はSeparator）で、
+// NamingCamel で区切りがない場合は単語の境界（大文字・数字・"_"）で区切る。
+func (n Naming) hasScenario(targetName, qname string) bool {
+	rest, ok := strings.CutPrefix(targetName, qname)
+	if !ok || rest == "" {
+		return false
+	}
+
+	if sep := n.scenarioSeparator(); sep != "" {
+		return strings.HasPrefix(rest, sep)
+	}
+
+	c := rest[0]
+
+	return c == '_' || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
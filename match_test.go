@@ -2,6 +2,49 @@ package testalign
 
 import "testing"
 
+func TestNamingMatcher_Match(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "Create", ReceiverType: "Service"},
+		{Name: "Create", ReceiverType: "service"},
+		{Name: "CreateAll", ReceiverType: "Service"},
+		{Name: "validate"},
+		{Name: "NewService", Constructs: "Service"},
+	}
+
+	tests := []struct {
+		naming Naming
+		test   string
+		want   *SourceFunc
+	}{
+		// CamelCase連結
+		{Naming{Style: NamingCamel, UnexportedPrefix: "_"}, "TestServiceCreate", &sourceFuncs[0]},
+		{Naming{Style: NamingCamel, UnexportedPrefix: "_"}, "TestServiceCreateError", &sourceFuncs[0]},
+		{Naming{Style: NamingCamel, UnexportedPrefix: "_"}, "TestServiceCreateAll", &sourceFuncs[2]},
+		{Naming{Style: NamingCamel, UnexportedPrefix: "_"}, "TestServiceCreated", nil},
+		{Naming{Style: NamingCamel, UnexportedPrefix: "_"}, "Test_validate", &sourceFuncs[3]},
+		// "__" でシナリオを区切る
+		{Naming{Separator: "_", ScenarioSeparator: "__"}, "TestService_Create__Error", &sourceFuncs[0]},
+		{Naming{Separator: "_", ScenarioSeparator: "__"}, "TestService_Create_Error", nil},
+		// gotests形式: 非公開の型のメソッドは "Test_type_Method"
+		{Naming{Style: NamingGotests, Separator: "_", UnexportedPrefix: "_"}, "Test_service_Create", &sourceFuncs[1]},
+		{Naming{Style: NamingGotests, Separator: "_", UnexportedPrefix: "_"}, "TestService_Create", &sourceFuncs[0]},
+		// 既定の形式では "Test_service_Create" はマッチしない
+		{defaultNaming, "Test_service_Create", nil},
+		// コンストラクタは型のメソッドNewとしても参照できる
+		{defaultNaming, "TestNewService", &sourceFuncs[4]},
+		{defaultNaming, "TestService_New", &sourceFuncs[4]},
+		{defaultNaming, "TestService_New_Error", &sourceFuncs[4]},
+		{Naming{Style: NamingCamel, UnexportedPrefix: "_"}, "TestServiceNew", &sourceFuncs[4]},
+	}
+
+	for _, tt := range tests {
+		got := NamingMatcher{Naming: tt.naming}.Match(TestFunc{Name: tt.test}, sourceFuncs)
+		if got != tt.want {
+			t.Errorf("%s (%s): got %v, want %v", tt.test, tt.naming.Style, got, tt.want)
+		}
+	}
+}
+
 func TestMatchTestFuncs_ExactMatch(t *testing.T) {
 	sourceFuncs := []SourceFunc{
 		{Name: "Create", ReceiverType: "Service"},
@@ -156,3 +199,27 @@ func TestMatchTestFuncs_TestOnly(t *testing.T) {
 		t.Errorf("Test（ターゲット名が空）: マッチすべきでない")
 	}
 }
+
+func TestMatchTypedTest(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "Parse"},
+		{Name: "Create", ReceiverType: "Service"},
+		{Name: "Create"},
+	}
+
+	tests := []struct {
+		tf   TestFunc
+		want *SourceFunc
+	}{
+		{TestFunc{Name: "TestCreate_Error", Prefix: "Test", TargetType: "Service"}, &sourceFuncs[1]},
+		// テスト対象の型のメソッドがない場合はパッケージ関数と照合する
+		{TestFunc{Name: "TestParse", Prefix: "Test", TargetType: "Parser"}, &sourceFuncs[0]},
+		{TestFunc{Name: "TestParse", Prefix: "Test", TargetType: "Service"}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := matchTypedTest(tt.tf, sourceFuncs, defaultNaming); got != tt.want {
+			t.Errorf("%s (%s): got %v, want %v", tt.tf.Name, tt.tf.TargetType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package testalign
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// MissingConfig はテストのないソース関数の検出（missingチェック）の設定を表す。
+type MissingConfig struct {
+	IncludeUnexported bool `yaml:"include_unexported"` // 非公開の関数・メソッドも対象にする
+	MethodsOnly       bool `yaml:"methods_only"`       // メソッドのみを対象にする
+	MinLines          int  `yaml:"min_lines"`          // 対象とする関数の最小行数（宣言から閉じ括弧まで）
+}
+
+// FindUntestedFuncs はTest関数のマッチ結果のいずれにも対応しないソース関数を宣言順に返す。
+// Benchmark・Fuzz・Example関数だけが対応するソース関数もテストのないものとみなす。
+func FindUntestedFuncs(sourceFuncs []SourceFunc, matches []MatchResult) []SourceFunc {
+	tested := make(map[token.Pos]bool)
+	for _, m := range matches {
+		if m.SourceFunc != nil && isTestKind(m.TestFunc) {
+			tested[m.SourceFunc.Pos] = true
+		}
+	}
+
+	var untested []SourceFunc
+	for _, sf := range sourceFuncs {
+		if !tested[sf.Pos] {
+			untested = append(untested, sf)
+		}
+	}
+
+	return untested
+}
+
+// isTestKind はテスト関数がTest関数、またはTest関数のt.Runサブテストか判定する。
+func isTestKind(tf TestFunc) bool {
+	if tf.Parent != "" {
+		prefix, _ := testPrefixOf(tf.Parent, testPrefixes)
+		return prefix == "Test"
+	}
+
+	return testKind(tf) == "Test"
+}
+
+// isMissingCandidate はソース関数がmissingチェックの対象かどうかを判定する。
+// 型・定数・変数の宣言は対象外。
+func (c MissingConfig) isMissingCandidate(sf SourceFunc) bool {
+	if sf.Kind != DeclFunc {
+		return false
+	}
+
+	if c.MethodsOnly && sf.ReceiverType == "" {
+		return false
+	}
+
+	if !c.IncludeUnexported {
+		if !token.IsExported(sf.Name) {
+			return false
+		}
+		if sf.ReceiverType != "" && !token.IsExported(sf.ReceiverType) {
+			return false
+		}
+	}
+
+	return sf.Lines >= c.MinLines
+}
+
+// checkMissing は対応するテストファイルが存在するソースファイルについて、
+// テスト関数のないソース関数を報告する。
+// 診断はソースファイル名順に、テストを追加すべき位置（宣言順で直前のソース関数のテスト関数）に報告する。
+// 直前にテストのあるソース関数がない場合は、対応するテストファイルのpackage句の位置に報告する。
+// ソース関数の宣言に "//testalign:ignore" ディレクティブが付いている場合は報告しない。
+// sourceFilesはソースファイル名から構文木へのマッピング。
+// matchesBySourceは、ソースファイル名からそのファイルに対応するテストファイル群のマッチ結果へのマッピング。
+// プラットフォーム別のファイルや型ごとにまとめたファイル（families）は、まとめた関数すべてを対象とし、
+// 複数のまとまりに含まれる関数は、いずれかのまとまりのテストファイルにテストがあれば報告せず、なければ1回だけ報告する。
+func checkMissing(pass *analysis.Pass, cfg *Config, sourceFiles, testFiles map[string]*ast.File, allSourceFuncs, families map[string][]SourceFunc, matchesBySource map[string][]MatchResult) {
+	directives := make(map[string]Directives)
+	reported := make(map[token.Pos]bool)
+
+	var allMatches []MatchResult
+	for _, matches := range matchesBySource {
+		allMatches = append(allMatches, matches...)
+	}
+
+	for _, sourceFileName := range slices.Sorted(maps.Keys(matchesBySource)) {
+		matches := matchesBySource[sourceFileName]
+		names := testFileNames(matches)
+		if len(names) == 0 {
+			continue
+		}
+
+		sourceFuncs := mappedSourceFuncs(sourceFileName, allSourceFuncs, families)
+
+		for _, sf := range FindUntestedFuncs(sourceFuncs, allMatches) {
+			if !cfg.Missing.isMissingCandidate(sf) || reported[sf.Pos] {
+				continue
+			}
+			reported[sf.Pos] = true
+
+			if file, ok := sourceFiles[sf.FileName]; ok {
+				ds, ok := directives[sf.FileName]
+				if !ok {
+					ds = ParseDirectives(file, pass.Fset)
+					directives[sf.FileName] = ds
+				}
+
+				if ds.Funcs[sf.Pos] != nil {
+					continue
+				}
+			}
+
+			msg := fmt.Sprintf("%s (%s) has no corresponding test in %s",
+				formatFuncRef(sf), formatSourcePos(pass.Fset, sf), strings.Join(names, ", "))
+
+			anchor := precedingTest(matches, sourceFuncs, sf)
+			if anchor == nil {
+				pass.Reportf(testFiles[names[0]].Package, "%s", msg)
+				continue
+			}
+
+			pass.Reportf(anchor.Pos, "%s; add it after %s", msg, formatTestRef(*anchor))
+		}
+	}
+}
+
+// precedingTest はソース関数sfのテストを追加すべき位置を示すテスト関数を返す。
+// sourceFuncsの宣言順でsfより前にあるソース関数のテスト関数のうち、最も後ろのソース関数に対応するもの
+// （同じソース関数のテスト関数が複数ある場合は最も後ろにあるもの）を返す。Test関数以外とt.Runサブテストは対象外で、
+// 該当するテスト関数がない場合はnilを返す。
+func precedingTest(matches []MatchResult, sourceFuncs []SourceFunc, sf SourceFunc) *TestFunc {
+	sourceIndex := buildSourceIndex(sourceFuncs)
+	target, ok := sourceIndex[sf.Pos]
+	if !ok {
+		return nil
+	}
+
+	var anchor *TestFunc
+	best := -1
+
+	for i, m := range matches {
+		if m.SourceFunc == nil || m.TestFunc.Parent != "" || !isTestKind(m.TestFunc) {
+			continue
+		}
+
+		idx, ok := sourceIndex[m.SourceFunc.Pos]
+		if !ok || idx >= target || idx < best || (idx == best && m.TestFunc.Pos < anchor.Pos) {
+			continue
+		}
+
+		anchor, best = &matches[i].TestFunc, idx
+	}
+
+	return anchor
+}
+
+// testFileNames はマッチ結果に含まれるテストファイル名をファイル名順に返す。
+func testFileNames(matches []MatchResult) []string {
+	var names []string
+	for _, m := range matches {
+		if !slices.Contains(names, m.TestFunc.FileName) {
+			names = append(names, m.TestFunc.FileName)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
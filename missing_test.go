@@ -0,0 +1,81 @@
+package testalign
+
+import (
+	"go/token"
+	"testing"
+)
+
+func TestFindUntestedFuncs(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "Create", ReceiverType: "Service", Pos: token.Pos(10)},
+		{Name: "Read", ReceiverType: "Service", Pos: token.Pos(20)},
+		{Name: "Delete", ReceiverType: "Service", Pos: token.Pos(30)},
+	}
+	matches := []MatchResult{
+		{TestFunc: TestFunc{Name: "TestService_Create"}, SourceFunc: &sourceFuncs[0]},
+		{TestFunc: TestFunc{Name: "TestService_Delete_Error"}, SourceFunc: &sourceFuncs[2]},
+		{TestFunc: TestFunc{Name: "Delete", Parent: "TestService"}, SourceFunc: &sourceFuncs[2]},
+		{TestFunc: TestFunc{Name: "TestUnrelated"}, SourceFunc: nil},
+		// Benchmark関数だけではテストのない関数とみなす
+		{TestFunc: TestFunc{Name: "BenchmarkService_Read"}, SourceFunc: &sourceFuncs[1]},
+	}
+
+	untested := FindUntestedFuncs(sourceFuncs, matches)
+
+	if len(untested) != 1 {
+		t.Fatalf("テストのない関数数: got %d, want 1", len(untested))
+	}
+	if untested[0].Name != "Read" {
+		t.Errorf("Name: got %q, want %q", untested[0].Name, "Read")
+	}
+}
+
+func TestMissingConfig_isMissingCandidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  MissingConfig
+		sf   SourceFunc
+		want bool
+	}{
+		{"公開関数", MissingConfig{}, SourceFunc{Name: "Parse", Lines: 1}, true},
+		{"非公開関数", MissingConfig{}, SourceFunc{Name: "parse", Lines: 1}, false},
+		{"非公開関数（unexported有効）", MissingConfig{IncludeUnexported: true}, SourceFunc{Name: "parse", Lines: 1}, true},
+		{"非公開型のメソッド", MissingConfig{}, SourceFunc{Name: "Run", ReceiverType: "worker", Lines: 1}, false},
+		{"関数（methods_only）", MissingConfig{MethodsOnly: true}, SourceFunc{Name: "Parse", Lines: 1}, false},
+		{"メソッド（methods_only）", MissingConfig{MethodsOnly: true}, SourceFunc{Name: "Run", ReceiverType: "Worker", Lines: 1}, true},
+		{"行数不足", MissingConfig{MinLines: 5}, SourceFunc{Name: "Parse", Lines: 4}, false},
+		{"行数充足", MissingConfig{MinLines: 5}, SourceFunc{Name: "Parse", Lines: 5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.isMissingCandidate(tt.sf); got != tt.want {
+				t.Errorf("isMissingCandidate: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_precedingTest(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "Open", Pos: token.Pos(10)},
+		{Name: "Get", Pos: token.Pos(20)},
+		{Name: "Put", Pos: token.Pos(30)},
+	}
+	matches := []MatchResult{
+		{TestFunc: TestFunc{Name: "TestOpen", Pos: token.Pos(100)}, SourceFunc: &sourceFuncs[0]},
+		{TestFunc: TestFunc{Name: "TestOpen_Error", Pos: token.Pos(110)}, SourceFunc: &sourceFuncs[0]},
+		{TestFunc: TestFunc{Name: "Open", Pos: token.Pos(120), Parent: "TestAll"}, SourceFunc: &sourceFuncs[0]},
+		{TestFunc: TestFunc{Name: "TestPut", Pos: token.Pos(130)}, SourceFunc: &sourceFuncs[2]},
+	}
+
+	// 直前のソース関数の最も後ろのテスト関数（サブテストは除く）
+	if got := precedingTest(matches, sourceFuncs, sourceFuncs[1]); got == nil || got.Name != "TestOpen_Error" {
+		t.Errorf("Get: got %v, want TestOpen_Error", got)
+	}
+
+	// 前にテストのあるソース関数がない場合はnil
+	if got := precedingTest(matches, sourceFuncs, sourceFuncs[0]); got != nil {
+		t.Errorf("Open: got %v, want nil", got)
+	}
+}
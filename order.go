@@ -3,15 +3,22 @@ package testalign
 // DetectOrderViolations はマッチ済みテスト関数の順序がソース関数の宣言順序と
 // 一致しているかを検証し、違反箇所を返す。
 //
-// ソース関数のインデックス列が単調非減少であることを検証する。
-// 違反箇所: ソースインデックスがそれまでの最大値より小さい位置。
+// ソースインデックス列の最長非減少部分列を求め、そこに含まれないテスト関数を
+// 移動が必要な最小のテスト関数集合として報告する。
+// 最長部分列が複数ある場合は、ファイル内で先に現れるテスト関数を残す。
+// 各違反には、移動先として直後（After）または直前（Before）に置くべきテスト関数を設定する。
 func DetectOrderViolations(matches []MatchResult, sourceFuncs []SourceFunc) []OrderViolation {
+	return detectOrderViolations(matches, sourceFuncs, KindOrder{})
+}
+
+// detectOrderViolations は種類の並べ方kindsに従って順序違反を検出する。
+func detectOrderViolations(matches []MatchResult, sourceFuncs []SourceFunc, kinds KindOrder) []OrderViolation {
 	// ソース関数のインデックスマップを構築
 	sourceIndex := buildSourceIndex(sourceFuncs)
 
-	var violations []OrderViolation
-	maxIdx := -1
-	var maxMatch *MatchResult
+	// マッチしたテスト関数とそのソースインデックスをファイル順に収集
+	var matched []*MatchResult
+	var indices []int
 
 	for i := range matches {
 		m := &matches[i]
@@ -24,23 +31,84 @@ func DetectOrderViolations(matches []MatchResult, sourceFuncs []SourceFunc) []Or
 			continue
 		}
 
-		if idx < maxIdx && maxMatch != nil {
-			violations = append(violations, OrderViolation{
-				TestFunc:      m.TestFunc,
-				SourceFunc:    *m.SourceFunc,
-				PrecedingTest: maxMatch,
-			})
+		matched = append(matched, m)
+		indices = append(indices, kinds.sortKey(m.TestFunc, idx, len(sourceFuncs)))
+	}
+
+	keep := longestNonDecreasing(indices)
+
+	var violations []OrderViolation
+
+	for i, m := range matched {
+		if keep[i] {
+			continue
+		}
+
+		v := OrderViolation{
+			TestFunc:   m.TestFunc,
+			SourceFunc: *m.SourceFunc,
 		}
 
-		if idx > maxIdx {
-			maxIdx = idx
-			maxMatch = m
+		// 残すテスト関数のうち、ソースインデックスがidx以下の最後のものの直後に移動する。
+		// 該当がなければ、残すテスト関数の先頭の直前に移動する。
+		for j := range matched {
+			if !keep[j] {
+				continue
+			}
+
+			if indices[j] <= indices[i] {
+				v.After = matched[j]
+			} else if v.Before == nil {
+				v.Before = matched[j]
+			}
 		}
+
+		if v.After != nil {
+			v.Before = nil
+		}
+
+		violations = append(violations, v)
 	}
 
 	return violations
 }
 
+// longestNonDecreasing は列の最長非減少部分列に含まれる要素を示すフラグを返す。
+// 同じ長さの部分列が複数ある場合は、位置の列が辞書順で最小のものを選ぶ。
+func longestNonDecreasing(seq []int) []bool {
+	n := len(seq)
+
+	// length[i] は seq[i] から始まる最長非減少部分列の長さ
+	length := make([]int, n)
+	best := 0
+
+	for i := n - 1; i >= 0; i-- {
+		length[i] = 1
+		for j := i + 1; j < n; j++ {
+			if seq[j] >= seq[i] && length[j]+1 > length[i] {
+				length[i] = length[j] + 1
+			}
+		}
+
+		best = max(best, length[i])
+	}
+
+	keep := make([]bool, n)
+	prev := -1
+
+	for i := 0; i < n && best > 0; i++ {
+		if length[i] != best || (prev >= 0 && seq[i] < seq[prev]) {
+			continue
+		}
+
+		keep[i] = true
+		prev = i
+		best--
+	}
+
+	return keep
+}
+
 // buildSourceIndex はソース関数のPosからインデックスへのマッピングを構築する。
 func buildSourceIndex(sourceFuncs []SourceFunc) map[any]int {
 	index := make(map[any]int, len(sourceFuncs))
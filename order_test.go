@@ -46,8 +46,11 @@ func TestDetectOrderViolations_SingleViolation(t *testing.T) {
 	if v.SourceFunc.Name != "Create" {
 		t.Errorf("SourceFunc.Name: got %q, want %q", v.SourceFunc.Name, "Create")
 	}
-	if v.PrecedingTest == nil || v.PrecedingTest.TestFunc.Name != "TestService_Delete" {
-		t.Errorf("PrecedingTest: 不正な値")
+	if v.After != nil {
+		t.Errorf("After: got %q, want nil", v.After.TestFunc.Name)
+	}
+	if v.Before == nil || v.Before.TestFunc.Name != "TestService_Delete" {
+		t.Errorf("Before: 不正な値")
 	}
 }
 
@@ -93,9 +96,11 @@ func TestDetectOrderViolations_MultipleViolations(t *testing.T) {
 		{Name: "A", Pos: token.Pos(10)},
 		{Name: "B", Pos: token.Pos(20)},
 		{Name: "C", Pos: token.Pos(30)},
+		{Name: "D", Pos: token.Pos(40)},
 	}
-	// C, A, B の順（CがmaxになるのでAもBも違反）
+	// D, C, A, B の順（A, B を残し、D と C を移動する）
 	matches := []MatchResult{
+		{TestFunc: TestFunc{Name: "TestD"}, SourceFunc: &sourceFuncs[3]},
 		{TestFunc: TestFunc{Name: "TestC"}, SourceFunc: &sourceFuncs[2]},
 		{TestFunc: TestFunc{Name: "TestA"}, SourceFunc: &sourceFuncs[0]},
 		{TestFunc: TestFunc{Name: "TestB"}, SourceFunc: &sourceFuncs[1]},
@@ -106,4 +111,46 @@ func TestDetectOrderViolations_MultipleViolations(t *testing.T) {
 	if len(violations) != 2 {
 		t.Fatalf("違反数: got %d, want 2", len(violations))
 	}
+
+	for i, name := range []string{"TestD", "TestC"} {
+		v := violations[i]
+		if v.TestFunc.Name != name {
+			t.Errorf("violations[%d].TestFunc.Name: got %q, want %q", i, v.TestFunc.Name, name)
+		}
+		if v.After == nil || v.After.TestFunc.Name != "TestB" {
+			t.Errorf("violations[%d].After: 不正な値", i)
+		}
+	}
+}
+
+func TestDetectOrderViolations_MinimalMoves(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "A", Pos: token.Pos(10)},
+		{Name: "B", Pos: token.Pos(20)},
+		{Name: "C", Pos: token.Pos(30)},
+		{Name: "D", Pos: token.Pos(40)},
+		{Name: "E", Pos: token.Pos(50)},
+	}
+	// 先頭に置かれたEだけが違反（従来の最大値比較ではA〜Dすべてが違反になる）
+	matches := []MatchResult{
+		{TestFunc: TestFunc{Name: "TestE"}, SourceFunc: &sourceFuncs[4]},
+		{TestFunc: TestFunc{Name: "TestA"}, SourceFunc: &sourceFuncs[0]},
+		{TestFunc: TestFunc{Name: "TestB"}, SourceFunc: &sourceFuncs[1]},
+		{TestFunc: TestFunc{Name: "TestC"}, SourceFunc: &sourceFuncs[2]},
+		{TestFunc: TestFunc{Name: "TestD"}, SourceFunc: &sourceFuncs[3]},
+	}
+
+	violations := DetectOrderViolations(matches, sourceFuncs)
+
+	if len(violations) != 1 {
+		t.Fatalf("違反数: got %d, want 1", len(violations))
+	}
+
+	v := violations[0]
+	if v.TestFunc.Name != "TestE" {
+		t.Errorf("TestFunc.Name: got %q, want %q", v.TestFunc.Name, "TestE")
+	}
+	if v.After == nil || v.After.TestFunc.Name != "TestD" {
+		t.Errorf("After: 不正な値")
+	}
 }
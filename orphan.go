@@ -0,0 +1,137 @@
+package testalign
+
+import "strings"
+
+// Orphan はどのソース関数にも対応しない "Type_Method" 形式のテスト関数を表す。
+// ソース関数の名前変更や削除によって対応先を失ったテストを想定している。
+type Orphan struct {
+	TestFunc   TestFunc
+	Suggestion *SourceFunc // 名前が最も近いソース関数（候補がない場合はnil）
+	NewName    string      // Suggestionに対応させる場合のテスト関数名
+}
+
+// FindOrphans はマッチしなかったテスト関数のうち、対応先を持つような名前
+// （区切り文字を含む "Type_Method" 形式）でありながらパッケージ内のどのソース関数にも
+// 対応しないものを返す。
+// パッケージ内の関数との対応付けにはmatcherを使い、名前の形式の判定と提案にはnamingを使う。
+// 各孤立テストには、編集距離が最も近いソース関数の修飾名を提案として設定する。
+// testifyスイートのメソッドとパッケージのExampleは対象外。Example関数の名前は命名規則の設定によらず
+// go docの形式（"T_M"）で判定する。
+func FindOrphans(matches []MatchResult, allFuncs []SourceFunc, matcher Matcher, naming Naming) []Orphan {
+	var orphans []Orphan
+
+	for _, m := range matches {
+		if m.SourceFunc != nil || m.TestFunc.Suite != "" {
+			continue
+		}
+
+		target := m.TestFunc.TargetName()
+		naming := naming
+		if m.TestFunc.isExample() {
+			if isPackageExample(target) {
+				continue
+			}
+			naming = defaultNaming
+		}
+
+		if !looksQualified(target, naming) || matcher.Match(m.TestFunc, allFuncs) != nil {
+			continue
+		}
+
+		o := Orphan{TestFunc: m.TestFunc}
+		if sf, rest := suggestSource(target, allFuncs, naming); sf != nil {
+			o.Suggestion = sf
+			o.NewName = strings.TrimSuffix(m.TestFunc.Name, target) + naming.QualifiedName(*sf) + rest
+		}
+
+		orphans = append(orphans, o)
+	}
+
+	return orphans
+}
+
+// looksQualified はターゲット名が "Type_Method" のように区切り文字で区切られた名前か判定する。
+// 非公開関数のプレフィックス（"_"）は区切りとみなさない。
+// 区切り文字を使わない NamingCamel では常にfalseを返す。
+func looksQualified(target string, naming Naming) bool {
+	if naming.Separator == "" || naming.Style == NamingCamel {
+		return false
+	}
+
+	name := strings.TrimPrefix(target, naming.UnexportedPrefix)
+	head, _, ok := strings.Cut(name, naming.Separator)
+
+	return ok && head != ""
+}
+
+// suggestSource はターゲット名に最も近い修飾名を持つソース関数を返す。
+// 修飾名と同じ区切り数だけターゲット名の先頭を取り出して編集距離を比較し、
+// 残りの部分（サブテスト名）をrestとして返す。
+// 距離が修飾名の長さの1/3（最低2）を超える候補は採用しない。型・定数・変数は候補にしない。
+func suggestSource(target string, allFuncs []SourceFunc, naming Naming) (best *SourceFunc, rest string) {
+	bestDist := -1
+
+	for i := range allFuncs {
+		if allFuncs[i].Kind != DeclFunc {
+			continue
+		}
+
+		qname := naming.QualifiedName(allFuncs[i])
+		head, tail := splitAtSeparator(target, naming.Separator, strings.Count(qname, naming.Separator))
+
+		dist := levenshtein(head, qname)
+		if dist > max(2, len(qname)/3) {
+			continue
+		}
+
+		if bestDist < 0 || dist < bestDist {
+			best, rest, bestDist = &allFuncs[i], tail, dist
+		}
+	}
+
+	return best, rest
+}
+
+// splitAtSeparator はsをn+1個目の区切り文字sepの位置で分割する。
+// tailは区切り文字から始まる残りの部分（区切りが足りない場合は空）。
+func splitAtSeparator(s, sep string, n int) (head, tail string) {
+	offset := 0
+	for k := 0; ; k++ {
+		i := strings.Index(s[offset:], sep)
+		if i < 0 {
+			return s, ""
+		}
+
+		if k == n {
+			return s[:offset+i], s[offset+i:]
+		}
+
+		offset += i + len(sep)
+	}
+}
+
+// levenshtein はaとbのレーベンシュタイン距離を返す。
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,99 @@
+package testalign
+
+import "testing"
+
+func TestFindOrphans(t *testing.T) {
+	allFuncs := []SourceFunc{
+		{Name: "Create", ReceiverType: "Service"},
+		{Name: "Delete", ReceiverType: "Service"},
+		{Name: "Place", ReceiverType: "Order"},
+	}
+	// マッピングされたソースファイルの関数（Service のみ）とのマッチ結果
+	matches := MatchTestFuncs([]TestFunc{
+		{Name: "TestService_Create"},
+		{Name: "TestService_Craete_Error"},
+		{Name: "TestOrder_Place"},
+		{Name: "TestWidget_Spin"},
+		{Name: "TestIntegration"},
+	}, allFuncs[:2])
+
+	orphans := FindOrphans(matches, allFuncs, NamingMatcher{Naming: defaultNaming}, defaultNaming)
+
+	// TestOrder_Place は別ファイルのソース関数に対応するので対象外
+	if len(orphans) != 2 {
+		t.Fatalf("孤立テスト数: got %d, want 2", len(orphans))
+	}
+
+	if orphans[0].TestFunc.Name != "TestService_Craete_Error" {
+		t.Errorf("orphans[0].TestFunc.Name: got %q", orphans[0].TestFunc.Name)
+	}
+	if orphans[0].Suggestion == nil || orphans[0].Suggestion.Name != "Create" {
+		t.Errorf("orphans[0].Suggestion: 不正な値")
+	}
+	if orphans[0].NewName != "TestService_Create_Error" {
+		t.Errorf("orphans[0].NewName: got %q, want %q", orphans[0].NewName, "TestService_Create_Error")
+	}
+
+	if orphans[1].TestFunc.Name != "TestWidget_Spin" {
+		t.Errorf("orphans[1].TestFunc.Name: got %q", orphans[1].TestFunc.Name)
+	}
+	if orphans[1].Suggestion != nil {
+		t.Errorf("orphans[1].Suggestion: got %q, want nil", orphans[1].Suggestion.Name)
+	}
+}
+
+func TestLooksQualified(t *testing.T) {
+	tests := []struct {
+		target string
+		want   bool
+	}{
+		{"Service_Create", true},
+		{"Service_Create_Error", true},
+		{"_helper_Empty", true},
+		{"_helper", false},
+		{"Integration", false},
+	}
+
+	for _, tt := range tests {
+		if got := looksQualified(tt.target, defaultNaming); got != tt.want {
+			t.Errorf("looksQualified(%q): got %v, want %v", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestSplitAtSeparator(t *testing.T) {
+	tests := []struct {
+		s, head, tail string
+		n             int
+	}{
+		{"Service_Create_Error", "Service_Create", "_Error", 1},
+		{"Service_Create", "Service_Create", "", 1},
+		{"_helper_Empty", "_helper", "_Empty", 1},
+		{"Parse_Empty", "Parse", "_Empty", 0},
+	}
+
+	for _, tt := range tests {
+		head, tail := splitAtSeparator(tt.s, "_", tt.n)
+		if head != tt.head || tail != tt.tail {
+			t.Errorf("splitAtSeparator(%q, %d): got (%q, %q), want (%q, %q)", tt.s, tt.n, head, tail, tt.head, tt.tail)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"Create", "Craete", 2},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q): got %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
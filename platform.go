@@ -0,0 +1,186 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// knownOS はファイル名の接尾辞（例: conn_linux.go）として解釈されるGOOSの一覧（go/build と同じ）。
+var knownOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+	"hurd": true, "illumos": true, "ios": true, "js": true, "linux": true,
+	"nacl": true, "netbsd": true, "openbsd": true, "plan9": true, "solaris": true,
+	"wasip1": true, "windows": true, "zos": true,
+}
+
+// knownArch はファイル名の接尾辞（例: conn_amd64.go）として解釈されるGOARCHの一覧（go/build と同じ）。
+var knownArch = map[string]bool{
+	"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true,
+	"arm64": true, "arm64be": true, "loong64": true, "mips": true, "mipsle": true,
+	"mips64": true, "mips64le": true, "mips64p32": true, "mips64p32le": true, "ppc": true,
+	"ppc64": true, "ppc64le": true, "riscv": true, "riscv64": true, "s390": true,
+	"s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+}
+
+// platformStem はプラットフォーム別のソースファイル名から接尾辞を除いたファイル名を返す。
+// 接尾辞は go/build と同じ "_GOOS"、"_GOARCH"、"_GOOS_GOARCH" に加え、
+// //go:build 制約に現れるタグ（例: "//go:build unix" の conn_unix.go）も対象とする。
+// プラットフォーム別のファイルでない場合はfileNameをそのまま返す。
+func platformStem(fileName string, file *ast.File) string {
+	stem, ok := strings.CutSuffix(fileName, ".go")
+	if !ok {
+		return fileName
+	}
+
+	// 先頭の要素（例: linux.go の "linux"）はファイル名の本体とみなし、接尾辞として除かない
+	parts := strings.Split(stem, "_")
+	n := len(parts)
+
+	switch {
+	case n >= 3 && knownOS[parts[n-2]] && knownArch[parts[n-1]]:
+		n -= 2
+	case n >= 2 && (knownOS[parts[n-1]] || knownArch[parts[n-1]]):
+		n--
+	case n >= 2 && slices.Contains(buildTags(file), parts[n-1]):
+		n--
+	default:
+		return fileName
+	}
+
+	return strings.Join(parts[:n], "_") + ".go"
+}
+
+// buildTags はファイルの //go:build 制約に現れるタグを返す。制約がない場合はnilを返す。
+func buildTags(file *ast.File) []string {
+	if file == nil {
+		return nil
+	}
+
+	for _, group := range file.Comments {
+		// 制約はパッケージ宣言より前に書く必要がある
+		if group.Pos() >= file.Package {
+			break
+		}
+
+		for _, c := range group.List {
+			if !constraint.IsGoBuild(c.Text) {
+				continue
+			}
+
+			expr, err := constraint.Parse(c.Text)
+			if err != nil {
+				return nil
+			}
+
+			return exprTags(expr)
+		}
+	}
+
+	return nil
+}
+
+// exprTags は制約式に現れるタグを返す（Evalは短絡評価するため式を直接たどる）。
+func exprTags(expr constraint.Expr) []string {
+	switch x := expr.(type) {
+	case *constraint.TagExpr:
+		return []string{x.Tag}
+	case *constraint.NotExpr:
+		return exprTags(x.X)
+	case *constraint.AndExpr:
+		return append(exprTags(x.X), exprTags(x.Y)...)
+	case *constraint.OrExpr:
+		return append(exprTags(x.X), exprTags(x.Y)...)
+	}
+
+	return nil
+}
+
+// platformFamilies はプラットフォーム別のソースファイル（例: conn.go、conn_linux.go、conn_windows.go）を
+// 接尾辞を除いたファイル名（例: conn.go）ごとにまとめ、その関数をファイル名順に結合して返す。
+// 接尾辞のないファイルを先頭とし、複数のファイルで宣言された同じ関数は最初のものだけを残す。
+//
+// excludedにはビルド制約で除外されたソースファイルを渡す。現在のプラットフォームでは
+// ビルドされない実装（例: Linux上の conn_windows.go）もテストファイルとの対応付けに使う。
+func platformFamilies(fset *token.FileSet, sourceFiles, excluded map[string]*ast.File, allSourceFuncs map[string][]SourceFunc) map[string][]SourceFunc {
+	members := make(map[string][]string)
+	for _, files := range []map[string]*ast.File{sourceFiles, excluded} {
+		for fileName, file := range files {
+			if stem := platformStem(fileName, file); stem != fileName {
+				members[stem] = append(members[stem], fileName)
+			}
+		}
+	}
+
+	families := make(map[string][]SourceFunc)
+	for stem, names := range members {
+		slices.Sort(names)
+		if sourceFiles[stem] != nil {
+			names = append([]string{stem}, names...)
+		}
+
+		type key struct {
+			kind     DeclKind
+			receiver string
+			name     string
+		}
+		seen := make(map[key]bool)
+
+		var funcs []SourceFunc
+		for _, fileName := range names {
+			fileFuncs, ok := allSourceFuncs[fileName]
+			if !ok && excluded[fileName] != nil {
+				fileFuncs = ExtractSourceFuncs(excluded[fileName], fset)
+			}
+
+			for _, sf := range fileFuncs {
+				k := key{sf.Kind, sf.ReceiverType, sf.Name}
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+				funcs = append(funcs, sf)
+			}
+		}
+
+		if len(funcs) > 0 {
+			families[stem] = funcs
+		}
+	}
+
+	return families
+}
+
+// parseExcludedFiles はビルド制約で除外されたパッケージのソースファイル（pass.IgnoredFiles）を解析する。
+// テストファイル、無視パターンに一致するファイル、パッケージ名の異なるファイル
+// （例: "//go:build ignore" の package main）は除く。
+func parseExcludedFiles(pass *analysis.Pass, cfg *Config) (map[string]*ast.File, error) {
+	excluded := make(map[string]*ast.File)
+
+	for _, path := range pass.IgnoredFiles {
+		fileName := filepath.Base(path)
+		if !strings.HasSuffix(fileName, ".go") || IsTestFile(fileName) || cfg.IsIgnored(path) {
+			continue
+		}
+
+		src, err := pass.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := parser.ParseFile(pass.Fset, path, src, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil || file.Name.Name != pass.Pkg.Name() {
+			continue
+		}
+
+		excluded[fileName] = file
+	}
+
+	return excluded, nil
+}
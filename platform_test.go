@@ -0,0 +1,96 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestPlatformStem(t *testing.T) {
+	tests := []struct {
+		fileName string
+		src      string
+		want     string
+	}{
+		{"conn.go", "package p", "conn.go"},
+		{"conn_linux.go", "package p", "conn.go"},
+		{"conn_amd64.go", "package p", "conn.go"},
+		{"conn_linux_amd64.go", "package p", "conn.go"},
+		{"net_conn_windows.go", "package p", "net_conn.go"},
+		// 先頭の要素は接尾辞とみなさない
+		{"linux.go", "package p", "linux.go"},
+		{"linux_amd64.go", "package p", "linux.go"},
+		// //go:build 制約のタグに一致する接尾辞
+		{"conn_unix.go", "//go:build unix\n\npackage p", "conn.go"},
+		{"conn_purego.go", "//go:build !amd64 || purego\n\npackage p", "conn.go"},
+		{"conn_unix.go", "package p", "conn_unix.go"},
+		{"conn_other.go", "//go:build !unix\n\npackage p", "conn_other.go"},
+	}
+
+	for _, tt := range tests {
+		file, err := parser.ParseFile(token.NewFileSet(), tt.fileName, tt.src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("パース失敗: %v", err)
+		}
+
+		if got := platformStem(tt.fileName, file); got != tt.want {
+			t.Errorf("platformStem(%q): got %q, want %q", tt.fileName, got, tt.want)
+		}
+	}
+}
+
+func TestPlatformFamilies(t *testing.T) {
+	sources := map[string]string{
+		"conn.go":         "package p\n\ntype Conn struct{}\n\nfunc (c *Conn) Close() {}\n",
+		"conn_linux.go":   "package p\n\nfunc dial() {}\n\nfunc listen() {}\n",
+		"conn_windows.go": "package p\n\nfunc dial() {}\n\nfunc listen() {}\n\nfunc pipeName() {}\n",
+		"server.go":       "package p\n\nfunc Serve() {}\n",
+	}
+
+	fset := token.NewFileSet()
+	sourceFiles := make(map[string]*ast.File)
+	excluded := make(map[string]*ast.File)
+	allSourceFuncs := make(map[string][]SourceFunc)
+
+	for name, src := range sources {
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("パース失敗: %v", err)
+		}
+
+		// Linux上で解析した場合と同様に conn_windows.go はビルド対象外とする
+		if name == "conn_windows.go" {
+			excluded[name] = file
+			continue
+		}
+
+		sourceFiles[name] = file
+		allSourceFuncs[name] = ExtractSourceFuncs(file, fset)
+	}
+
+	got := platformFamilies(fset, sourceFiles, excluded, allSourceFuncs)
+
+	if len(got) != 1 {
+		t.Fatalf("ファミリー数: got %d, want 1", len(got))
+	}
+
+	want := []struct{ name, file string }{
+		{"Conn", "conn.go"},
+		{"Close", "conn.go"},
+		{"dial", "conn_linux.go"},
+		{"listen", "conn_linux.go"},
+		{"pipeName", "conn_windows.go"},
+	}
+
+	funcs := got["conn.go"]
+	if len(funcs) != len(want) {
+		t.Fatalf("関数数: got %d, want %d", len(funcs), len(want))
+	}
+
+	for i, w := range want {
+		if funcs[i].Name != w.name || funcs[i].FileName != w.file {
+			t.Errorf("funcs[%d]: got %s (%s), want %s (%s)", i, funcs[i].Name, funcs[i].FileName, w.name, w.file)
+		}
+	}
+}
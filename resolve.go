@@ -0,0 +1,213 @@
+package testalign
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// テスト対象の解決方式。Config.Resolve に指定する。
+const (
+	ResolveNames = "names" // 宣言に書かれた名前だけで照合する（既定）
+	ResolveTypes = "types" // 型情報でレシーバー型・エイリアス・昇格メソッドを解決し、曖昧なテスト名を報告する
+)
+
+// resolveTargets は型情報を使ってメソッドのレシーバー型を解決し、
+// メソッドをテストする際に使える別のレシーバー型名（AltReceivers）を設定する。
+//
+//   - レシーバーにエイリアス（type Svc = Service）を書いたメソッドは、元の型（Service）のメソッドとし、
+//     書かれた名前を別名とする。
+//   - パッケージ内で宣言された型のエイリアスは、その型のすべてのメソッドの別名とする。
+//   - 埋め込みによって別の型に昇格するメソッドは、昇格先の型を別名とする
+//     （type Service struct{ *Logger } の Logger.Log は TestService_Log でもテストできる）。
+//
+// filesはソースファイル名から構文木へのマッピング。
+func resolveTargets(files map[string]*ast.File, info *types.Info, allSourceFuncs map[string][]SourceFunc) {
+	// メソッドの名前の位置（types.Func.Pos）からソース関数への対応
+	methods := make(map[token.Pos]*SourceFunc)
+
+	for fileName, file := range files {
+		funcs := allSourceFuncs[fileName]
+
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil {
+				continue
+			}
+
+			fn, ok := info.Defs[funcDecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			for i := range funcs {
+				if funcs[i].Pos != funcDecl.Pos() {
+					continue
+				}
+
+				if name := receiverTypeName(fn); name != "" && name != funcs[i].ReceiverType {
+					if funcs[i].ReceiverType != "" {
+						funcs[i].AltReceivers = append(funcs[i].AltReceivers, funcs[i].ReceiverType)
+					}
+					funcs[i].ReceiverType = name
+				}
+
+				methods[fn.Pos()] = &funcs[i]
+			}
+		}
+	}
+
+	// 型名ごとのエイリアスと、パッケージで宣言された名前付き型
+	aliases := make(map[string][]string)
+	var named []*types.TypeName
+
+	for _, obj := range info.Defs {
+		tn, ok := obj.(*types.TypeName)
+		if !ok || tn.Pkg() == nil || tn.Parent() != tn.Pkg().Scope() {
+			continue
+		}
+
+		if !tn.IsAlias() {
+			named = append(named, tn)
+			continue
+		}
+
+		if target, ok := types.Unalias(tn.Type()).(*types.Named); ok && target.Obj().Pkg() == tn.Pkg() {
+			aliases[target.Obj().Name()] = append(aliases[target.Obj().Name()], tn.Name())
+		}
+	}
+
+	for _, sf := range methods {
+		sf.AltReceivers = append(sf.AltReceivers, aliases[sf.ReceiverType]...)
+	}
+
+	// 埋め込みによって昇格するメソッド
+	for _, tn := range named {
+		mset := types.NewMethodSet(types.NewPointer(tn.Type()))
+		for i := range mset.Len() {
+			sel := mset.At(i)
+			if len(sel.Index()) < 2 {
+				continue
+			}
+
+			if sf, ok := methods[sel.Obj().Pos()]; ok {
+				sf.AltReceivers = append(sf.AltReceivers, tn.Name())
+				sf.AltReceivers = append(sf.AltReceivers, aliases[tn.Name()]...)
+			}
+		}
+	}
+
+	for _, sf := range methods {
+		slices.Sort(sf.AltReceivers)
+		sf.AltReceivers = slices.Compact(sf.AltReceivers)
+	}
+}
+
+// receiverTypeName はメソッドのレシーバーの名前付き型の名前を返す（エイリアスとポインタは解決する）。
+func receiverTypeName(fn *types.Func) string {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return ""
+	}
+
+	return namedTypeName(recv.Type())
+}
+
+// namedTypeName は型、またはポインタの指す型が名前付き型の場合にその名前を返す（エイリアスは解決する）。
+func namedTypeName(typ types.Type) string {
+	typ = types.Unalias(typ)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = types.Unalias(ptr.Elem())
+	}
+
+	if named, ok := typ.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+
+	return ""
+}
+
+// Ambiguity はテスト名が複数のソース関数に同じ強さでマッチすることを表す。
+type Ambiguity struct {
+	TestFunc   TestFunc
+	Candidates []SourceFunc // マッチするソース関数（宣言順）
+}
+
+// FindAmbiguousTests はテスト名が複数のソース関数に同じ強さでマッチするテスト関数を返す
+// （例: TestA_B_C は A.B_C と A_B.C のどちらにも一致する）。
+// 完全一致が複数ある場合と、完全一致がなくシナリオ付きの最長一致が複数ある場合を報告する。
+// t.RunサブテストとExample関数は対象外とする。
+func FindAmbiguousTests(testFuncs []TestFunc, sourceFuncs []SourceFunc, naming Naming) []Ambiguity {
+	var ambiguities []Ambiguity
+
+	for _, tf := range testFuncs {
+		if tf.Parent != "" || tf.TargetType != "" || tf.isExample() {
+			continue
+		}
+
+		if candidates := matchCandidates(tf.TargetName(), sourceFuncs, naming); len(candidates) > 1 {
+			ambiguities = append(ambiguities, Ambiguity{TestFunc: tf, Candidates: candidates})
+		}
+	}
+
+	return ambiguities
+}
+
+// matchCandidates は matchTestToSource と同じ規則で、最も強くマッチするソース関数をすべて返す。
+// 同じ宣言位置のソース関数（別名による重複）は1つにまとめる。
+func matchCandidates(targetName string, sourceFuncs []SourceFunc, naming Naming) []SourceFunc {
+	if targetName == "" {
+		return nil
+	}
+
+	var exact, scenario []SourceFunc
+	bestLen := 0
+
+	for _, sf := range sourceFuncs {
+		for _, qname := range naming.matchNames(sf) {
+			switch {
+			case qname == targetName:
+				exact = appendCandidate(exact, sf)
+			case sf.Kind != DeclType && naming.hasScenario(targetName, qname):
+				if len(qname) > bestLen {
+					scenario, bestLen = nil, len(qname)
+				}
+				if len(qname) == bestLen {
+					scenario = appendCandidate(scenario, sf)
+				}
+			}
+		}
+	}
+
+	if len(exact) > 0 {
+		return exact
+	}
+
+	return scenario
+}
+
+// appendCandidate はcandidatesにsfを追加する（同じ宣言位置のものは追加しない）。
+func appendCandidate(candidates []SourceFunc, sf SourceFunc) []SourceFunc {
+	if slices.ContainsFunc(candidates, func(c SourceFunc) bool { return c.Pos == sf.Pos }) {
+		return candidates
+	}
+
+	return append(candidates, sf)
+}
+
+// checkAmbiguity は複数のソース関数に同じ強さでマッチするテスト関数を報告する。
+func checkAmbiguity(pass *analysis.Pass, cfg *Config, testFuncs []TestFunc, sourceFuncs []SourceFunc) {
+	for _, a := range FindAmbiguousTests(testFuncs, sourceFuncs, cfg.Naming) {
+		refs := make([]string, len(a.Candidates))
+		for i, sf := range a.Candidates {
+			refs[i] = fmt.Sprintf("%s (%s)", formatFuncRef(sf), formatSourcePos(pass.Fset, sf))
+		}
+
+		pass.Reportf(a.TestFunc.Pos, "%s is ambiguous: it may test %s", formatTestRef(a.TestFunc), strings.Join(refs, " or "))
+	}
+}
@@ -0,0 +1,115 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"testing"
+)
+
+func TestResolveTargets(t *testing.T) {
+	src := `package example
+
+type Service struct {
+	*Logger
+}
+
+type Svc = Service
+
+func (s *Svc) Stop() {}
+
+type Logger struct{}
+
+func (l *Logger) Log() {}
+
+type Client struct{}
+
+func (c Client) Do() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "service.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("パース失敗: %v", err)
+	}
+
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	if _, err := new(types.Config).Check("example", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("型チェック失敗: %v", err)
+	}
+
+	allSourceFuncs := map[string][]SourceFunc{"service.go": ExtractSourceFuncs(file, fset)}
+	resolveTargets(map[string]*ast.File{"service.go": file}, info, allSourceFuncs)
+
+	tests := []struct {
+		name         string
+		receiverType string
+		alts         []string
+	}{
+		// エイリアスで書かれたレシーバーは元の型に解決し、書かれた名前を別名とする
+		{"Stop", "Service", []string{"Svc"}},
+		// 埋め込みによって昇格するメソッドは昇格先の型とそのエイリアスを別名とする
+		{"Log", "Logger", []string{"Service", "Svc"}},
+		{"Do", "Client", nil},
+	}
+
+	for _, tt := range tests {
+		i := slices.IndexFunc(allSourceFuncs["service.go"], func(sf SourceFunc) bool { return sf.Name == tt.name })
+		if i < 0 {
+			t.Fatalf("%s が見つからない", tt.name)
+		}
+
+		sf := allSourceFuncs["service.go"][i]
+		if sf.ReceiverType != tt.receiverType {
+			t.Errorf("%s.ReceiverType: got %q, want %q", tt.name, sf.ReceiverType, tt.receiverType)
+		}
+		if !slices.Equal(sf.AltReceivers, tt.alts) {
+			t.Errorf("%s.AltReceivers: got %v, want %v", tt.name, sf.AltReceivers, tt.alts)
+		}
+	}
+}
+
+func TestFindAmbiguousTests(t *testing.T) {
+	sourceFuncs := []SourceFunc{
+		{Name: "B_C", ReceiverType: "A", Pos: 1},
+		{Name: "C", ReceiverType: "A_B", Pos: 2},
+		{Name: "Get", ReceiverType: "Store", Pos: 3},
+		{Name: "Get", ReceiverType: "Store_Cache", Pos: 4},
+		{Name: "Log", ReceiverType: "Logger", Pos: 5, AltReceivers: []string{"Service"}},
+		{Name: "Log", ReceiverType: "Service", Pos: 6},
+	}
+
+	testFuncs := []TestFunc{
+		{Name: "TestA_B_C"},
+		{Name: "TestA_B_C_Error"},
+		{Name: "TestStore_Get"},
+		{Name: "TestStore_Cache_Get"},
+		{Name: "TestService_Log"},
+		{Name: "TestLogger_Log"},
+		{Name: "TestMissing"},
+	}
+
+	got := make(map[string][]string)
+	for _, a := range FindAmbiguousTests(testFuncs, sourceFuncs, defaultNaming) {
+		for _, sf := range a.Candidates {
+			got[a.TestFunc.Name] = append(got[a.TestFunc.Name], sf.ReceiverType+"."+sf.Name)
+		}
+	}
+
+	want := map[string][]string{
+		"TestA_B_C":       {"A.B_C", "A_B.C"},
+		"TestA_B_C_Error": {"A.B_C", "A_B.C"},
+		"TestService_Log": {"Logger.Log", "Service.Log"},
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("曖昧なテスト: got %v, want %v", got, want)
+	}
+
+	for name, w := range want {
+		if !slices.Equal(got[name], w) {
+			t.Errorf("%s: got %v, want %v", name, got[name], w)
+		}
+	}
+}
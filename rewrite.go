@@ -0,0 +1,186 @@
+package testalign
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"path/filepath"
+	"slices"
+)
+
+// RewriteFiles はパッケージのファイル群からテストファイルを並べ替えた結果を返す。
+// 戻り値はファイルパスから書き換え後のソースへのマッピングで、変更のないファイルは含まない。
+// wrong_fileチェックが有効な場合は、別のテストファイルに置くべきテスト関数の移動も行い、
+// 新たに作成したテストファイルも戻り値に含める。
+// コンストラクタをまとめるには cfg.Constructors と cfg.TypesInfo を設定する。
+// ビルド制約で除外されたプラットフォーム別のソースファイルは cfg.PlatformFiles に渡す。
+// filesにはソースファイルとテストファイルの両方を渡す。cfgがnilの場合は既定の設定を使う。
+func RewriteFiles(fset *token.FileSet, files []*ast.File, readFile func(string) ([]byte, error), cfg *Config) (map[string][]byte, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	allSourceFuncs := make(map[string][]SourceFunc)
+	sourceFiles := make(map[string]*ast.File)
+	var testFiles []*ast.File
+
+	for _, file := range files {
+		path := fset.Position(file.Pos()).Filename
+		if cfg.IsIgnored(path) {
+			continue
+		}
+
+		fileName := filepath.Base(path)
+		if IsTestFile(fileName) {
+			testFiles = append(testFiles, file)
+			continue
+		}
+
+		sourceFiles[fileName] = file
+		if funcs := ExtractSourceFuncs(file, fset); len(funcs) > 0 {
+			allSourceFuncs[fileName] = funcs
+		}
+	}
+
+	arrangeSourceFuncs(cfg, sourceFiles, cfg.TypesInfo, allSourceFuncs)
+
+	excluded := make(map[string]*ast.File)
+	for _, file := range cfg.PlatformFiles {
+		if path := fset.Position(file.Pos()).Filename; !cfg.IsIgnored(path) {
+			excluded[filepath.Base(path)] = file
+		}
+	}
+	families := platformFamilies(fset, sourceFiles, excluded, allSourceFuncs)
+
+	var testFileNames []string
+	for _, file := range testFiles {
+		testFileNames = append(testFileNames, filepath.Base(fset.Position(file.Pos()).Filename))
+	}
+	families = applyTypeMapping(cfg, testFileNames, allSourceFuncs, families)
+
+	srcs := make(map[string][]byte)
+	for _, file := range testFiles {
+		path := fset.File(file.Pos()).Name()
+
+		src, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		srcs[path] = src
+	}
+
+	suites := findSuiteTypes(testFiles, cfg.Suite)
+
+	// 別のテストファイルに置くべきテスト関数を移動（必要ならファイルを作成）してから並べ替える
+	contents := srcs
+	if cfg.Enabled(CheckWrongFile) {
+		contents = moveMisplacedTests(fset, testFiles, srcs, allSourceFuncs, families, cfg)
+	}
+
+	parsed := make(map[string]*ast.File)
+	for _, file := range testFiles {
+		parsed[fset.File(file.Pos()).Name()] = file
+	}
+
+	result := make(map[string][]byte)
+
+	for _, path := range slices.Sorted(maps.Keys(contents)) {
+		src := contents[path]
+
+		// 移動によって内容が変わったファイルは再解析する
+		file := parsed[path]
+		if file == nil || !bytes.Equal(src, srcs[path]) {
+			var err error
+			if file, err = parser.ParseFile(fset, path, src, parser.ParseComments); err != nil {
+				return nil, err
+			}
+		}
+
+		out := src
+		if sourceFuncs := collectSourceFuncsForTestFile(cfg.sourceFileIn(filepath.Base(path), allSourceFuncs, families), allSourceFuncs, families); len(sourceFuncs) > 0 {
+			out = rewriteTestFile(fset, file, src, sourceFuncs, cfg, suites)
+		}
+
+		if orig, ok := srcs[path]; !ok || !bytes.Equal(out, orig) {
+			result[path] = out
+		}
+	}
+
+	return result, nil
+}
+
+// RewriteTestFile はマッチしたテスト関数をソース関数の宣言順序に並べ替えたソースを返す。
+//
+// マッチしたテスト関数が占める位置（スロット）だけを入れ替えるため、
+// TestMainやヘルパー関数、型・変数宣言、独立したコメント、空行による区切りは元の位置に残る。
+// 同じソース関数に対応するテスト関数同士は元の相対順序を保つ。
+// 抑制ディレクティブの付いたテスト関数も元の位置に残し、ファイル単位で抑制されている場合は書き換えない。
+func RewriteTestFile(fset *token.FileSet, file *ast.File, src []byte, sourceFuncs []SourceFunc) []byte {
+	cfg := DefaultConfig()
+
+	return rewriteTestFile(fset, file, src, sourceFuncs, cfg, findSuiteTypes([]*ast.File{file}, cfg.Suite))
+}
+
+// rewriteTestFile は設定cfgのプレフィックスと命名規則でマッチングして並べ替える。
+// suitesはパッケージ内のtestifyスイートの型（findSuiteTypes の結果）。
+func rewriteTestFile(fset *token.FileSet, file *ast.File, src []byte, sourceFuncs []SourceFunc, cfg *Config, suites map[string]string) []byte {
+	// 抑制ディレクティブの付いたファイル・テスト関数は並べ替えない
+	ds := ParseDirectives(file, fset)
+	if ds.File != nil {
+		return src
+	}
+
+	tf := fset.File(file.Pos())
+	sourceIndex := buildSourceIndex(sourceFuncs)
+	matches := matchTestFuncs(extractTestFuncs(file, fset, cfg.TestPrefixes, suites), sourceFuncs, cfg.matcher())
+	matches = filterIgnored(matches, ds, token.NoPos)
+
+	type slot struct {
+		decl  *ast.FuncDecl
+		index int
+	}
+
+	var slots []slot
+	for _, m := range matches {
+		if m.SourceFunc == nil {
+			continue
+		}
+
+		idx, ok := sourceIndex[m.SourceFunc.Pos]
+		decl := findFuncDecl(file, m.TestFunc.Pos)
+		if !ok || decl == nil {
+			continue
+		}
+
+		slots = append(slots, slot{decl: decl, index: cfg.KindOrder.sortKey(m.TestFunc, idx, len(sourceFuncs))})
+	}
+
+	sorted := slices.Clone(slots)
+	slices.SortStableFunc(sorted, func(a, b slot) int {
+		return a.index - b.index
+	})
+
+	var buf bytes.Buffer
+	last := 0
+
+	for i, s := range slots {
+		start := tf.Offset(lineStart(tf, declStart(s.decl)))
+		end := lineEnd(src, tf.Offset(s.decl.End()))
+
+		text := declText(tf, src, sorted[i].decl)
+		if !bytes.HasSuffix(text, []byte("\n")) {
+			text = append(text, '\n')
+		}
+
+		buf.Write(src[last:start])
+		buf.Write(text)
+		last = end
+	}
+
+	buf.Write(src[last:])
+
+	return buf.Bytes()
+}
@@ -0,0 +1,275 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestRewriteFiles_NoChange(t *testing.T) {
+	sources := map[string]string{
+		"service.go": `package example
+
+type Service struct{}
+
+func (s *Service) Create() {}
+
+func (s *Service) Delete() {}
+`,
+		"service_test.go": `package example
+
+func TestService_Create(t *testing.T) {}
+
+func TestService_Delete(t *testing.T) {}
+`,
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range []string{"service.go", "service_test.go"} {
+		file, err := parser.ParseFile(fset, name, sources[name], parser.ParseComments)
+		if err != nil {
+			t.Fatalf("パース失敗: %v", err)
+		}
+		files = append(files, file)
+	}
+
+	readFile := func(name string) ([]byte, error) { return []byte(sources[name]), nil }
+
+	got, err := RewriteFiles(fset, files, readFile, nil)
+	if err != nil {
+		t.Fatalf("RewriteFiles: %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("書き換え対象数: got %d, want 0", len(got))
+	}
+}
+
+func TestRewriteFiles_WrongFile(t *testing.T) {
+	sources := map[string]string{
+		"order.go": `package example
+
+type Order struct{}
+
+func (o *Order) Place() {}
+
+func (o *Order) Ship() {}
+`,
+		"invoice.go": `package example
+
+func Issue() {}
+`,
+		"order_test.go": `package example
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOrder_Place(t *testing.T) { _ = strings.ToLower("A") }
+
+func TestIssue(t *testing.T) { _ = strings.ToUpper("a") }
+`,
+		"misc_test.go": `package example
+
+import "testing"
+
+func TestOrder_Ship(t *testing.T) {}
+
+func TestIntegration(t *testing.T) {}
+`,
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range []string{"order.go", "invoice.go", "order_test.go", "misc_test.go"} {
+		file, err := parser.ParseFile(fset, name, sources[name], parser.ParseComments)
+		if err != nil {
+			t.Fatalf("パース失敗: %v", err)
+		}
+		files = append(files, file)
+	}
+
+	readFile := func(name string) ([]byte, error) { return []byte(sources[name]), nil }
+
+	cfg := DefaultConfig()
+	cfg.Checks[CheckWrongFile] = true
+
+	got, err := RewriteFiles(fset, files, readFile, cfg)
+	if err != nil {
+		t.Fatalf("RewriteFiles: %v", err)
+	}
+
+	want := map[string]string{
+		// 既存のテストファイルへ移動し、宣言順序に並べ替える
+		"order_test.go": `package example
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOrder_Place(t *testing.T) { _ = strings.ToLower("A") }
+
+func TestOrder_Ship(t *testing.T) {}
+`,
+		"misc_test.go": `package example
+
+import "testing"
+
+func TestIntegration(t *testing.T) {}
+`,
+		// 移動先のテストファイルがない場合は必要なインポートとともに作成する
+		"invoice_test.go": `package example
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIssue(t *testing.T) { _ = strings.ToUpper("a") }
+`,
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("書き換え対象数: got %d, want %d", len(got), len(want))
+	}
+
+	for name, w := range want {
+		if string(got[name]) != w {
+			t.Errorf("%s:\ngot:\n%s\nwant:\n%s", name, got[name], w)
+		}
+	}
+}
+
+func TestRewriteFiles_KindOrder(t *testing.T) {
+	sources := map[string]string{
+		"parser.go": `package example
+
+func Parse() {}
+
+func Format() {}
+`,
+		"parser_test.go": `package example
+
+func BenchmarkFormat(b *testing.B) {}
+
+func TestParse(t *testing.T) {}
+
+func BenchmarkParse(b *testing.B) {}
+
+func TestFormat(t *testing.T) {}
+`,
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range []string{"parser.go", "parser_test.go"} {
+		file, err := parser.ParseFile(fset, name, sources[name], parser.ParseComments)
+		if err != nil {
+			t.Fatalf("パース失敗: %v", err)
+		}
+		files = append(files, file)
+	}
+
+	readFile := func(name string) ([]byte, error) { return []byte(sources[name]), nil }
+
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{KindLayoutInterleaved, `package example
+
+func TestParse(t *testing.T) {}
+
+func BenchmarkParse(b *testing.B) {}
+
+func TestFormat(t *testing.T) {}
+
+func BenchmarkFormat(b *testing.B) {}
+`},
+		{KindLayoutSegregated, `package example
+
+func TestParse(t *testing.T) {}
+
+func TestFormat(t *testing.T) {}
+
+func BenchmarkParse(b *testing.B) {}
+
+func BenchmarkFormat(b *testing.B) {}
+`},
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.KindOrder.Layout = tt.layout
+
+		got, err := RewriteFiles(fset, files, readFile, cfg)
+		if err != nil {
+			t.Fatalf("RewriteFiles: %v", err)
+		}
+
+		if string(got["parser_test.go"]) != tt.want {
+			t.Errorf("%s:\ngot:\n%s\nwant:\n%s", tt.layout, got["parser_test.go"], tt.want)
+		}
+	}
+}
+
+func TestRewriteTestFile(t *testing.T) {
+	src := `package example
+
+import "testing"
+
+func TestMain(m *testing.M) {}
+
+// TestC はdocコメント付き。
+func TestC(t *testing.T) {}
+
+func TestA_Second(t *testing.T) {}
+
+// 独立したコメント
+
+func helper() {}
+
+func TestB(t *testing.T) {}
+
+func TestA_First(t *testing.T) {}
+`
+	want := `package example
+
+import "testing"
+
+func TestMain(m *testing.M) {}
+
+func TestA_Second(t *testing.T) {}
+
+func TestA_First(t *testing.T) {}
+
+// 独立したコメント
+
+func helper() {}
+
+func TestB(t *testing.T) {}
+
+// TestC はdocコメント付き。
+func TestC(t *testing.T) {}
+`
+	sourceFuncs := []SourceFunc{
+		{Name: "A", Pos: token.Pos(10)},
+		{Name: "B", Pos: token.Pos(20)},
+		{Name: "C", Pos: token.Pos(30)},
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example_test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("パース失敗: %v", err)
+	}
+
+	got := string(RewriteTestFile(fset, file, []byte(src), sourceFuncs))
+	if got != want {
+		t.Errorf("RewriteTestFile:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
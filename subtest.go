@@ -0,0 +1,132 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// Umbrella は型名を対象とするテスト関数（例: TestService）と、その本体のt.Runサブテストを表す。
+type Umbrella struct {
+	TestFunc TestFunc
+	Subtests []TestFunc // 呼び出し順のサブテスト
+}
+
+// FindUmbrellaTests はテスト関数のうち、ソース関数のレシーバー型を対象とするもの（アンブレラテスト）を探し、
+// 本体のt.Runサブテストを抽出して返す。サブテストのない関数は含まない。
+//
+// サブテスト名は定数で指定されたもののみ対象とし、go testと同様に空白を "_" に置き換える。
+// infoがnilの場合は、文字列リテラルで指定されたもののみ対象とする。
+func FindUmbrellaTests(file *ast.File, info *types.Info, testFuncs []TestFunc, sourceFuncs []SourceFunc, naming Naming) []Umbrella {
+	receivers := make(map[string]bool)
+	for _, sf := range sourceFuncs {
+		if sf.ReceiverType != "" {
+			receivers[sf.ReceiverType] = true
+		}
+	}
+
+	var umbrellas []Umbrella
+
+	for _, tf := range testFuncs {
+		if tf.Suite != "" || tf.Parent != "" {
+			continue
+		}
+
+		typeName := tf.TargetName()
+		if !receivers[typeName] {
+			typeName = strings.TrimPrefix(typeName, naming.UnexportedPrefix)
+		}
+		if !receivers[typeName] {
+			continue
+		}
+
+		decl := findFuncDecl(file, tf.Pos)
+		if decl == nil {
+			continue
+		}
+
+		if subtests := extractSubtests(decl, info, tf, typeName); len(subtests) > 0 {
+			umbrellas = append(umbrellas, Umbrella{TestFunc: tf, Subtests: subtests})
+		}
+	}
+
+	return umbrellas
+}
+
+// extractSubtests はテスト関数の本体から、第1引数（*testing.T）に対するt.Runの呼び出しを抽出する。
+// 同じ名前の引数を持つ関数リテラル（ネストしたサブテストの本体など）の中は探索しない。
+func extractSubtests(decl *ast.FuncDecl, info *types.Info, parent TestFunc, typeName string) []TestFunc {
+	params := decl.Type.Params.List
+	if len(params) == 0 || len(params[0].Names) == 0 || params[0].Names[0].Name == "_" {
+		return nil
+	}
+
+	tName := params[0].Names[0].Name
+	var subtests []TestFunc
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return !declaresParam(n.Type, tName)
+		case *ast.CallExpr:
+			if !isSelector(n.Fun, tName, "Run") || len(n.Args) != 2 {
+				return true
+			}
+
+			name, ok := subtestName(n.Args[0], info)
+			if !ok || name == "" {
+				return true
+			}
+
+			subtests = append(subtests, TestFunc{
+				Name:       strings.ReplaceAll(name, " ", "_"),
+				Pos:        n.Pos(),
+				FileName:   parent.FileName,
+				Parent:     parent.Name,
+				TargetType: typeName,
+			})
+		}
+
+		return true
+	})
+
+	return subtests
+}
+
+// subtestName はt.Runの第1引数が定数の場合にその文字列を返す。
+// infoがnilの場合は文字列リテラルのみ扱う。
+func subtestName(expr ast.Expr, info *types.Info) (string, bool) {
+	if info != nil {
+		tv, ok := info.Types[expr]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return "", false
+		}
+
+		return constant.StringVal(tv.Value), true
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+
+	name, err := strconv.Unquote(lit.Value)
+
+	return name, err == nil
+}
+
+// declaresParam は関数型が名前nameの引数を宣言しているか判定する。
+func declaresParam(typ *ast.FuncType, name string) bool {
+	for _, field := range typ.Params.List {
+		for _, id := range field.Names {
+			if id.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
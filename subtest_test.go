@@ -0,0 +1,69 @@
+package testalign
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestFindUmbrellaTests(t *testing.T) {
+	src := `package example
+
+import "testing"
+
+func TestService(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		t.Run("nested", func(t *testing.T) {})
+	})
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {})
+	}
+
+	t.Run("Delete all", func(st *testing.T) {})
+}
+
+func Test_store(t *testing.T) {
+	t.Run("Get", func(t *testing.T) {})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {})
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "service_test.go", src, 0)
+	if err != nil {
+		t.Fatalf("パース失敗: %v", err)
+	}
+
+	sourceFuncs := []SourceFunc{
+		{Name: "Create", ReceiverType: "Service"},
+		{Name: "Get", ReceiverType: "store"},
+		{Name: "Parse"},
+	}
+
+	umbrellas := FindUmbrellaTests(file, nil, ExtractTestFuncs(file, fset), sourceFuncs, defaultNaming)
+
+	// TestParse は型を対象としないので対象外
+	if len(umbrellas) != 2 {
+		t.Fatalf("アンブレラテスト数: got %d, want 2", len(umbrellas))
+	}
+
+	var names []string
+	for _, sub := range umbrellas[0].Subtests {
+		names = append(names, sub.Name)
+		if sub.Parent != "TestService" || sub.TargetType != "Service" {
+			t.Errorf("%s: got (%q, %q), want (TestService, Service)", sub.Name, sub.Parent, sub.TargetType)
+		}
+	}
+
+	// 定数でない名前とネストしたサブテストは対象外、空白は "_" に置き換える
+	if len(names) != 2 || names[0] != "Create" || names[1] != "Delete_all" {
+		t.Errorf("サブテスト: got %v, want [Create Delete_all]", names)
+	}
+
+	if got := umbrellas[1].Subtests[0].TargetType; got != "store" {
+		t.Errorf("Test_store の対象の型: got %q, want %q", got, "store")
+	}
+}
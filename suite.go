@@ -0,0 +1,134 @@
+package testalign
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+)
+
+// testifySuitePath はtestifyのsuiteパッケージのインポートパス。
+const testifySuitePath = "github.com/stretchr/testify/suite"
+
+// SuiteConfig はtestifyスイートのメソッドの対応付けの設定を表す。
+type SuiteConfig struct {
+	// Suffixes はスイート型名からテスト対象の型名を求める際に取り除く接尾辞（先に一致したものを使う）。
+	// 例: "ServiceSuite" → "Service"
+	Suffixes []string `yaml:"suffixes"`
+}
+
+// defaultSuiteSuffixes はスイート型名の既定の接尾辞。
+var defaultSuiteSuffixes = []string{"TestSuite", "Suite"}
+
+// suiteTarget はスイート型名からテスト対象の型名を返す。
+// どの接尾辞にも一致しない場合はスイート型名をそのまま返す。
+func (c SuiteConfig) suiteTarget(suiteType string) string {
+	for _, suffix := range c.Suffixes {
+		if target, ok := strings.CutSuffix(suiteType, suffix); ok && target != "" {
+			return target
+		}
+	}
+
+	return suiteType
+}
+
+// findSuiteTypes はテストファイル群からtestifyスイートの型を探し、
+// スイート型名からテスト対象の型名へのマッピングを返す。
+//
+// suite.Suite を埋め込んだ構造体型と、suite.Run の第2引数（new(T)、&T{}、T{}）に渡された型をスイートとみなす。
+// 型の宣言と suite.Run の呼び出しは別のファイルにあってもよい。
+func findSuiteTypes(files []*ast.File, cfg SuiteConfig) map[string]string {
+	suites := make(map[string]string)
+
+	for _, file := range files {
+		name, ok := suiteImportName(file)
+		if !ok {
+			continue
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				if embedsSuite(n, name) {
+					suites[n.Name.Name] = cfg.suiteTarget(n.Name.Name)
+				}
+			case *ast.CallExpr:
+				if isSelector(n.Fun, name, "Run") && len(n.Args) == 2 {
+					if typeName := suiteArgType(n.Args[1]); typeName != "" {
+						suites[typeName] = cfg.suiteTarget(typeName)
+					}
+				}
+			}
+
+			return true
+		})
+	}
+
+	return suites
+}
+
+// suiteImportName はファイルでtestifyのsuiteパッケージを参照する名前を返す。
+func suiteImportName(file *ast.File) (string, bool) {
+	for _, spec := range file.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err == nil && p == testifySuitePath {
+			return importName(spec), true
+		}
+	}
+
+	return "", false
+}
+
+// embedsSuite は型宣言が pkg.Suite（またはそのポインタ）を埋め込んだ構造体か判定する。
+func embedsSuite(spec *ast.TypeSpec, pkg string) bool {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return false
+	}
+
+	for _, field := range st.Fields.List {
+		if len(field.Names) != 0 {
+			continue
+		}
+
+		typ := field.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+
+		if isSelector(typ, pkg, "Suite") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// suiteArgType は suite.Run に渡されたスイートの式から型名を返す。
+// new(T)、&T{}、T{} の形式に対応し、それ以外は空文字を返す。
+func suiteArgType(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return extractReceiverType(e.Type)
+	case *ast.CallExpr:
+		if id, ok := e.Fun.(*ast.Ident); ok && id.Name == "new" && len(e.Args) == 1 {
+			return extractReceiverType(e.Args[0])
+		}
+	}
+
+	return ""
+}
+
+// isSelector は式が pkg.name の形式か判定する。
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+
+	id, ok := sel.X.(*ast.Ident)
+
+	return ok && id.Name == pkg
+}
@@ -0,0 +1,76 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestSuiteConfig_suiteTarget(t *testing.T) {
+	cfg := SuiteConfig{Suffixes: defaultSuiteSuffixes}
+
+	tests := []struct {
+		suite string
+		want  string
+	}{
+		{"ServiceSuite", "Service"},
+		{"ServiceTestSuite", "Service"},
+		{"Suite", "Suite"},
+		{"ServiceTests", "ServiceTests"},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.suiteTarget(tt.suite); got != tt.want {
+			t.Errorf("suiteTarget(%q): got %q, want %q", tt.suite, got, tt.want)
+		}
+	}
+}
+
+func TestFindSuiteTypes(t *testing.T) {
+	fset := token.NewFileSet()
+	decls, err := parser.ParseFile(fset, "a_test.go", `package example
+
+import testify "github.com/stretchr/testify/suite"
+
+type ServiceSuite struct {
+	testify.Suite
+}
+
+type OrderSuite struct{}
+
+type plain struct{}
+`, 0)
+	if err != nil {
+		t.Fatalf("パース失敗: %v", err)
+	}
+
+	runner, err := parser.ParseFile(fset, "b_test.go", `package example
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestOrder(t *testing.T) { suite.Run(t, &OrderSuite{}) }
+
+func TestCart(t *testing.T) { suite.Run(t, new(cartSuite)) }
+`, 0)
+	if err != nil {
+		t.Fatalf("パース失敗: %v", err)
+	}
+
+	got := findSuiteTypes([]*ast.File{decls, runner}, SuiteConfig{Suffixes: defaultSuiteSuffixes})
+
+	want := map[string]string{"ServiceSuite": "Service", "OrderSuite": "Order", "cartSuite": "cart"}
+	if len(got) != len(want) {
+		t.Errorf("スイート数: got %d, want %d (%v)", len(got), len(want), got)
+	}
+
+	for suite, target := range want {
+		if got[suite] != target {
+			t.Errorf("%s: got %q, want %q", suite, got[suite], target)
+		}
+	}
+}
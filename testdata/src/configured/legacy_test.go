@@ -0,0 +1,8 @@
+package configured
+
+import "testing"
+
+// ignore に一致するため検証対象外
+func TestWidget__Close__Legacy(t *testing.T) {}
+
+func TestWidget__Open__Legacy(t *testing.T) {}
@@ -0,0 +1,7 @@
+package configured // want package:"testalign source order"
+
+type Widget struct{}
+
+func (w *Widget) Open() error { return nil }
+
+func (w *Widget) Close() error { return nil }
@@ -0,0 +1,12 @@
+package configured
+
+import "testing"
+
+func TestWidget__Close(t *testing.T) {}
+
+func TestWidget__Open__Twice(t *testing.T) {} // want `TestWidget__Open__Twice corresponds to Widget\.Open \(widget\.go:\d+\) and should be placed before TestWidget__Close which corresponds to Widget\.Close \(widget\.go:\d+\)`
+
+// Benchmark は test_prefixes に含まれないため検証対象外
+func BenchmarkWidget__Close(b *testing.B) {}
+
+func BenchmarkWidget__Open(b *testing.B) {}
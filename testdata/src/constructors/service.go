@@ -0,0 +1,19 @@
+package constructors // want package:"testalign source order"
+
+type Service struct{}
+
+func (s *Service) Create() {}
+
+func (s *Service) Delete() {}
+
+// NewService は型の後に宣言されていても型のテストとしてまとめられる
+func NewService() *Service { return &Service{} }
+
+type client struct{}
+
+func (c *client) Do() {}
+
+func newClient() (*client, error) { return &client{}, nil }
+
+// 戻り値の型が名前と一致しないためコンストラクタではない
+func NewLimit() int { return 0 }
@@ -0,0 +1,15 @@
+package constructors
+
+import "testing"
+
+func TestService_Create(t *testing.T) {}
+
+func TestNewService(t *testing.T) {} // want `TestNewService corresponds to NewService \(service.go:10\) and should be placed before TestService_Create which corresponds to Service.Create \(service.go:5\)`
+
+func TestService_Delete(t *testing.T) {}
+
+func TestService_New_Error(t *testing.T) {} // want `TestService_New_Error corresponds to NewService \(service.go:10\) and should be placed before TestService_Create which corresponds to Service.Create \(service.go:5\)`
+
+func Test_newClient(t *testing.T) {}
+
+func TestNewLimit(t *testing.T) {}
@@ -0,0 +1,7 @@
+package contiguity // want package:"testalign source order"
+
+type Handler struct{}
+
+func (h *Handler) Get() {}
+
+func (h *Handler) Put() {}
@@ -0,0 +1,17 @@
+package contiguity
+
+import "testing"
+
+func TestHandler_Get_Success(t *testing.T) {}
+
+func TestIntegration(t *testing.T) {}
+
+func TestHandler_Get_Error(t *testing.T) {} // want `TestHandler_Get_Error is separated from TestHandler_Get_Success, which also corresponds to Handler.Get, by TestIntegration`
+
+func TestHandler_Put_Error_NotFound(t *testing.T) {}
+
+func TestHandler_Put_Timeout(t *testing.T) {}
+
+func TestHandler_Put_Success(t *testing.T) {} // want `TestHandler_Put_Success should be placed before TestHandler_Put_Error_NotFound \(scenario order: Success, Error\)`
+
+func TestHandler_Put(t *testing.T) {} // want `TestHandler_Put should be placed before TestHandler_Put_Error_NotFound \(scenario order: Success, Error\)`
@@ -0,0 +1,7 @@
+package custommatcher // want package:"testalign source order"
+
+type Service struct{}
+
+func (s *Service) Create() {}
+
+func (s *Service) Delete() {}
@@ -0,0 +1,9 @@
+package custommatcher
+
+import "testing"
+
+func TestServiceDelete(t *testing.T) {} // want `TestServiceDelete corresponds to Service.Delete \(service.go:7\) and should be placed after TestServiceCreateTwice which corresponds to Service.Create \(service.go:5\)`
+
+func TestServiceCreate(t *testing.T) {}
+
+func TestServiceCreateTwice(t *testing.T) {}
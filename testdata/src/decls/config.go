@@ -0,0 +1,19 @@
+package decls // want package:"testalign source order"
+
+import "time"
+
+// DefaultTimeout は既定のタイムアウト。
+var DefaultTimeout = 5 * time.Second
+
+const (
+	MaxRetries = 3
+	minBackoff = time.Millisecond
+)
+
+type Config struct {
+	Timeout time.Duration
+}
+
+func (c *Config) Validate() error { return nil }
+
+func Load() *Config { return &Config{Timeout: DefaultTimeout} }
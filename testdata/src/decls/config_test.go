@@ -0,0 +1,16 @@
+package decls
+
+import "testing"
+
+func TestDefaultTimeout(t *testing.T) {}
+
+func TestConfig_Validate(t *testing.T) {}
+
+func TestConfig(t *testing.T) {} // want `TestConfig corresponds to Config \(config.go:13\) and should be placed after TestDefaultTimeout which corresponds to DefaultTimeout \(config.go:6\)`
+
+func Test_minBackoff(t *testing.T) {} // want `Test_minBackoff corresponds to minBackoff \(config.go:10\) and should be placed after TestDefaultTimeout which corresponds to DefaultTimeout \(config.go:6\)`
+
+func TestLoad(t *testing.T) {}
+
+// 型は完全一致のみ対応するため対象外
+func TestConfig_Reload(t *testing.T) {}
@@ -0,0 +1,11 @@
+package examples // want package:"testalign source order"
+
+type Service struct{}
+
+func New() *Service { return &Service{} }
+
+func (s *Service) Create() {}
+
+func (s *Service) Delete() {}
+
+func suffix() {}
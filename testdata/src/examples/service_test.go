@@ -0,0 +1,13 @@
+package examples
+
+func Example() {}
+
+func ExampleService_Delete() {} // want `ExampleService_Delete corresponds to Service.Delete \(service.go:9\) and should be placed after ExampleService_Create_retry which corresponds to Service.Create \(service.go:7\)`
+
+func ExampleNew() {}
+
+func Example_suffix() {}
+
+func ExampleService_Create() {}
+
+func ExampleService_Create_retry() {}
@@ -0,0 +1,19 @@
+package exercise // want package:"testalign source order"
+
+type Service struct {
+	items map[string]string
+}
+
+func NewService() *Service { return &Service{items: make(map[string]string)} }
+
+func (s *Service) Create(key, value string) { s.items[key] = value }
+
+func (s *Service) Update(key, value string) bool {
+	if _, ok := s.items[key]; !ok {
+		return false
+	}
+	s.items[key] = value
+	return true
+}
+
+func (s *Service) Delete(key string) { delete(s.items, key) }
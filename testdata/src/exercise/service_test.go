@@ -0,0 +1,45 @@
+package exercise
+
+import "testing"
+
+func TestNewService(t *testing.T) {
+	if NewService() == nil {
+		t.Fatal("nil")
+	}
+}
+
+func TestService_Create(t *testing.T) { // want `TestService_Create never references Service\.Create \(service\.go:\d+\); it calls Service\.Update most often`
+	s := NewService()
+	s.Update("a", "1")
+	s.Update("b", "2")
+}
+
+func TestService_Update(t *testing.T) {
+	s := NewService()
+	mustUpdate(t, s, "a")
+}
+
+func TestService_Delete(t *testing.T) {
+	s := NewService()
+	del := s.Delete
+	del("a")
+}
+
+func TestService_Delete_Fixture(t *testing.T) {
+	fixture[string]{key: "a"}.delete(t, NewService())
+}
+
+func mustUpdate(t *testing.T, s *Service, key string) {
+	t.Helper()
+	if !s.Update(key, "v") {
+		t.Fatalf("update %s", key)
+	}
+}
+
+// ジェネリック型のメソッドのヘルパーもたどる
+type fixture[K ~string] struct{ key K }
+
+func (f fixture[K]) delete(t *testing.T, s *Service) {
+	t.Helper()
+	s.Delete(string(f.key))
+}
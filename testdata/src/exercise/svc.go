@@ -0,0 +1,5 @@
+package exercise
+
+type Svc = Service
+
+func (s *Svc) Stop() { s.items = nil }
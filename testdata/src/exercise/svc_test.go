@@ -0,0 +1,9 @@
+package exercise
+
+import "testing"
+
+// エイリアスをレシーバーに書いたメソッドも、元の型のメソッドの参照とみなす
+func TestSvc_Stop(t *testing.T) {
+	s := NewService()
+	s.Stop()
+}
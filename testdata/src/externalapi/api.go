@@ -7,3 +7,7 @@ func (a *API) Get() error { return nil }
 func (a *API) Post() error { return nil }
 
 func (a *API) Delete() error { return nil }
+
+func (a *API) Put() error {
+	return nil
+}
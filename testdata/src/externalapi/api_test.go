@@ -7,8 +7,8 @@ import (
 
 var _ = externalapi.API{}
 
-func TestAPI_Delete(t *testing.T) {}
+func TestAPI_Delete(t *testing.T) {} // want `TestAPI_Delete corresponds to API\.Delete \(api\.go:\d+\) and should be placed after TestAPI_Post which corresponds to API\.Post \(api\.go:\d+\)` `API\.Put \(api\.go:\d+\) has no corresponding test in api_test\.go; add it after TestAPI_Delete`
 
-func TestAPI_Get(t *testing.T) {} // want `TestAPI_Get corresponds to API\.Get \(api\.go:\d+\) but appears before TestAPI_Delete which corresponds to API\.Delete \(api\.go:\d+\)`
+func TestAPI_Get(t *testing.T) {}
 
-func TestAPI_Post(t *testing.T) {} // want `TestAPI_Post corresponds to API\.Post \(api\.go:\d+\) but appears before TestAPI_Delete which corresponds to API\.Delete \(api\.go:\d+\)`
+func TestAPI_Post(t *testing.T) {}
@@ -0,0 +1,5 @@
+package fallback // want package:"testalign source order"
+
+func Alpha() {}
+
+func Apex() {}
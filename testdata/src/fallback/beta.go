@@ -0,0 +1,5 @@
+package fallback
+
+func Beta() {}
+
+func Bravo() {}
@@ -0,0 +1,13 @@
+package fallback
+
+import "testing"
+
+// misc.go は存在しないため、全ソースファイルの関数をファイル名順（alpha.go → beta.go）に結合して検証する。
+
+func TestAlpha(t *testing.T) {}
+
+func TestBeta(t *testing.T) {}
+
+func TestApex(t *testing.T) {} // want `TestApex corresponds to Apex \(alpha\.go:\d+\) and should be placed after TestAlpha which corresponds to Alpha \(alpha\.go:\d+\)`
+
+func TestBravo(t *testing.T) {}
@@ -0,0 +1,11 @@
+package fix // want package:"testalign source order"
+
+type Store struct{}
+
+func (s *Store) Open() error { return nil }
+
+func (s *Store) Get() error { return nil }
+
+func (s *Store) Put() error { return nil }
+
+func (s *Store) Close() error { return nil }
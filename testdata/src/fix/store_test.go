@@ -0,0 +1,16 @@
+package fix
+
+import "testing"
+
+// TestStore_Get はキーの取得を検証する。
+func TestStore_Get(t *testing.T) {}
+
+func TestStore_Put(t *testing.T) {}
+
+// TestStore_Open はストアのオープンを検証する。
+// 複数行のdocコメントも一緒に移動する。
+func TestStore_Open(t *testing.T) { // want `TestStore_Open corresponds to Store\.Open \(store\.go:\d+\) and should be placed before TestStore_Get which corresponds to Store\.Get \(store\.go:\d+\)`
+	t.Log("open")
+}
+
+func TestStore_Close(t *testing.T) {}
@@ -0,0 +1,11 @@
+package gotests // want package:"testalign source order"
+
+type store struct{}
+
+func (s *store) get() {}
+
+func (s *store) put() {}
+
+type Cache struct{}
+
+func (c *Cache) Load() {}
@@ -0,0 +1,9 @@
+package gotests
+
+import "testing"
+
+func Test_store_put(t *testing.T) {}
+
+func Test_store_get__Missing(t *testing.T) {} // want `Test_store_get__Missing corresponds to store.get \(store.go:5\) and should be placed before Test_store_put which corresponds to store.put \(store.go:7\)`
+
+func TestCache_Load(t *testing.T) {}
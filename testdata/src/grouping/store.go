@@ -0,0 +1,14 @@
+package grouping // want package:"testalign source order"
+
+type Order struct{}
+
+func (o *Order) Create() {}
+
+type User struct{}
+
+func (u *User) Get() {}
+
+// Cancel はUserのメソッドの後に宣言されている
+func (o *Order) Cancel() {}
+
+func Open() {}
@@ -0,0 +1,12 @@
+package grouping
+
+import "testing"
+
+func TestOrder_Create(t *testing.T) {}
+
+func TestUser_Get(t *testing.T) {}
+
+func TestOrder_Cancel(t *testing.T) {} // want `TestOrder_Cancel tests Order but is separated from TestOrder_Create by TestUser_Get, which tests User`
+
+// パッケージ関数のテストは型のまとまりを分断しない
+func TestOpen(t *testing.T) {}
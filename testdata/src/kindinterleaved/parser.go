@@ -0,0 +1,5 @@
+package kindinterleaved // want package:"testalign source order"
+
+func Parse() {}
+
+func Format() {}
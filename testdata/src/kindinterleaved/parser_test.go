@@ -0,0 +1,15 @@
+package kindinterleaved
+
+import "testing"
+
+func BenchmarkParse(b *testing.B) {} // want `BenchmarkParse corresponds to Parse \(parser.go:3\) and should be placed after FuzzParse which corresponds to Parse \(parser.go:3\)`
+
+func TestParse(t *testing.T) {}
+
+func FuzzParse(f *testing.F) {}
+
+func TestFormat(t *testing.T) {}
+
+func ExampleFormat() {}
+
+func BenchmarkFormat(b *testing.B) {} // want `BenchmarkFormat corresponds to Format \(parser.go:5\) and should be placed after TestFormat which corresponds to Format \(parser.go:5\)`
@@ -0,0 +1,5 @@
+package kindsegregated // want package:"testalign source order"
+
+func Parse() {}
+
+func Format() {}
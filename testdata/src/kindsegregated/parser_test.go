@@ -0,0 +1,11 @@
+package kindsegregated
+
+import "testing"
+
+func TestParse(t *testing.T) {}
+
+func BenchmarkParse(b *testing.B) {}
+
+func TestFormat(t *testing.T) {} // want `TestFormat corresponds to Format \(parser.go:5\) and should be placed after TestParse which corresponds to Parse \(parser.go:3\)`
+
+func BenchmarkFormat(b *testing.B) {}
@@ -0,0 +1,7 @@
+package layout // want package:"testalign source order"
+
+type Cache struct{}
+
+func (c *Cache) Get() {}
+
+func (c *Cache) Put() {}
@@ -0,0 +1,38 @@
+package layout
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCache_Get(t *testing.T) {
+	c := newCache(t)
+	_ = c
+	_ = fixture{name: "get"}
+
+	// 後で宣言されたパッケージの変数と同じ名前のローカル変数は参照とみなさない
+	cases := []string{"a"}
+	_ = cases
+}
+
+func TestMain(m *testing.M) { os.Exit(m.Run()) } // want `TestMain should be placed before TestCache_Get`
+
+type fixture struct{ name string } // want `fixture is declared after its first use in TestCache_Get`
+
+// testingの型の引数を持ってもHelperを呼び出さない関数はヘルパーではない
+func run(t *testing.T) {}
+
+func newCache(tb testing.TB) *Cache { return &Cache{} } // want `helper newCache should be placed at the end of the file, after TestCache_Put`
+
+func TestCache_Put(t *testing.T) {
+	assertEmpty(t, defaultKey)
+}
+
+// defaultKey はTestCache_Putより前に宣言する必要がある
+var defaultKey = "key" // want `defaultKey is declared after its first use in TestCache_Put`
+
+var cases = []string{"b"}
+
+func assertEmpty(t *testing.T, key string) {
+	t.Helper()
+}
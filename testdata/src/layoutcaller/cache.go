@@ -0,0 +1,7 @@
+package layoutcaller // want package:"testalign source order"
+
+type Cache struct{}
+
+func (c *Cache) Get() {}
+
+func (c *Cache) Put() {}
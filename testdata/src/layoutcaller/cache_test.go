@@ -0,0 +1,32 @@
+package layoutcaller
+
+import (
+	"os"
+	"testing"
+)
+
+func mustGet(t *testing.T, c *Cache) { // want `helper mustGet should be placed directly after TestCache_Get, its first caller`
+	t.Helper()
+	c.Get()
+}
+
+func TestMain(m *testing.M) { // want `TestMain should be placed after TestCache_Put`
+	// ヘルパーと同じ名前のローカル変数は呼び出しとみなさない
+	assertSize := m.Run()
+	os.Exit(assertSize)
+}
+
+func TestCache_Get(t *testing.T) {
+	mustGet(t, &Cache{})
+	mustPut(t, &Cache{})
+	assertSize(t, &Cache{})
+}
+
+func mustPut(t testing.TB, c *Cache) {
+	c.Put()
+}
+
+// 同じ呼び出し元のヘルパーが続く場合は直後とみなす
+func assertSize(t testing.TB, c *Cache) {}
+
+func TestCache_Put(t *testing.T) {}
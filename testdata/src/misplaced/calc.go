@@ -0,0 +1,9 @@
+package misplaced // want package:"testalign source order"
+
+func Add() int { return 0 }
+
+func Sub() int { return 0 }
+
+func Mul() int { return 0 }
+
+func Div() int { return 0 }
@@ -0,0 +1,14 @@
+package misplaced
+
+import "testing"
+
+// TestDiv は先頭に置かれているが、移動が必要なのはこの関数だけ。
+func TestDiv(t *testing.T) {} // want `TestDiv corresponds to Div \(calc\.go:\d+\) and should be placed after TestMul which corresponds to Mul \(calc\.go:\d+\)`
+
+func TestAdd(t *testing.T) {}
+
+func TestSub(t *testing.T) {}
+
+func TestMul(t *testing.T) {}
+
+func helper() {}
@@ -0,0 +1,41 @@
+package missing // want package:"testalign source order"
+
+type Account struct{ balance int }
+
+func NewAccount() *Account {
+	return &Account{}
+}
+
+func (a *Account) Deposit(n int) {
+	a.balance += n
+}
+
+func (a *Account) Withdraw(n int) {
+	a.balance -= n
+}
+
+// ベンチマークしかないため報告する
+func (a *Account) Transfer(to *Account, n int) {
+	a.balance -= n
+	to.balance += n
+}
+
+//testalign:ignore テストは統合テストで行う
+func (a *Account) Close() {
+	a.balance = 0
+}
+
+// 行数が min_lines 未満のため対象外
+func (a *Account) Balance() int { return a.balance }
+
+// 非公開メソッドは対象外
+func (a *Account) audit() {
+	_ = a.balance
+}
+
+type ledger struct{}
+
+// 非公開型のメソッドは対象外
+func (l *ledger) Post() {
+	_ = l
+}
@@ -0,0 +1,7 @@
+package missing // want `NewAccount \(account\.go:\d+\) has no corresponding test in account_test\.go$`
+
+import "testing"
+
+func TestAccount_Deposit(t *testing.T) {} // want `Account\.Withdraw \(account\.go:\d+\) has no corresponding test in account_test\.go; add it after TestAccount_Deposit` `Account\.Transfer \(account\.go:\d+\) has no corresponding test in account_test\.go; add it after TestAccount_Deposit`
+
+func BenchmarkAccount_Transfer(b *testing.B) {}
@@ -0,0 +1,6 @@
+package missing
+
+// 対応するテストファイルがないため対象外
+func Format() string {
+	return ""
+}
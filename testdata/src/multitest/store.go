@@ -0,0 +1,11 @@
+package multitest // want package:"testalign source order"
+
+type Store struct{}
+
+func (s *Store) Get(key string) string { return "" }
+
+func (s *Store) Put(key, value string) {}
+
+func (s *Store) Delete(key string) {}
+
+func (s *Store) compact() {}
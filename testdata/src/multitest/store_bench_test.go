@@ -0,0 +1,7 @@
+package multitest
+
+import "testing"
+
+func BenchmarkStore_compact(b *testing.B) {}
+
+func BenchmarkStore_Get(b *testing.B) {} // want `BenchmarkStore_Get corresponds to Store\.Get \(store\.go:\d+\) and should be placed before BenchmarkStore_compact which corresponds to Store\.compact \(store\.go:\d+\)`
@@ -0,0 +1,5 @@
+package multitest
+
+import "testing"
+
+func TestStore_Put(t *testing.T) {} // want `TestStore_Put corresponds to Store\.Put \(store\.go:\d+\), which falls between TestStore_Get and TestStore_compact in store_test\.go` `Store\.Delete \(store\.go:\d+\) has no corresponding test in store_bench_test\.go, store_internal_test\.go, store_test\.go; add it after TestStore_Put`
@@ -0,0 +1,7 @@
+package multitest
+
+import "testing"
+
+func TestStore_Get(t *testing.T) {}
+
+func TestStore_compact(t *testing.T) {}
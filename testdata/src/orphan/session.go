@@ -0,0 +1,9 @@
+package orphan // want package:"testalign source order"
+
+type Session struct{}
+
+func (s *Session) Start() error { return nil }
+
+func (s *Session) Refresh() error { return nil }
+
+func validate() bool { return true }
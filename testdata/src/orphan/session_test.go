@@ -0,0 +1,23 @@
+package orphan
+
+import "testing"
+
+func TestSession_Start(t *testing.T) {}
+
+// Renew は Refresh に名前変更された
+func TestSession_Renew(t *testing.T) {} // want `TestSession_Renew does not correspond to any source function; did you mean TestSession_Refresh \(Session\.Refresh\)\?`
+
+func TestSession_Refresh_Expired(t *testing.T) {}
+
+func TestSesion_Start_Twice(t *testing.T) {} // want `TestSesion_Start_Twice does not correspond to any source function; did you mean TestSession_Start_Twice \(Session\.Start\)\?`
+
+// 提案する名前のテストがすでにある場合は名前を変更しない
+func TestSession_Strat(t *testing.T) {} // want `TestSession_Strat does not correspond to any source function; did you mean TestSession_Start \(Session\.Start\)\?`
+
+func Test_validat_Empty(t *testing.T) {} // want `Test_validat_Empty does not correspond to any source function; did you mean Test_validate_Empty \(validate\)\?`
+
+// 区切りを含まない名前は対象外
+func TestIntegration(t *testing.T) {}
+
+// 近い名前のソース関数がない場合は提案しない
+func TestCache_Evict(t *testing.T) {} // want `TestCache_Evict does not correspond to any source function$`
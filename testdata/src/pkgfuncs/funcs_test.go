@@ -2,8 +2,8 @@ package pkgfuncs
 
 import "testing"
 
-func TestApplyConfig(t *testing.T) {}
+func TestApplyConfig(t *testing.T) {} // want `TestApplyConfig corresponds to ApplyConfig \(funcs\.go:\d+\) and should be placed after TestValidateConfig which corresponds to ValidateConfig \(funcs\.go:\d+\)`
 
-func TestParseConfig(t *testing.T) {} // want `TestParseConfig corresponds to ParseConfig \(funcs\.go:\d+\) but appears before TestApplyConfig which corresponds to ApplyConfig \(funcs\.go:\d+\)`
+func TestParseConfig(t *testing.T) {}
 
-func TestValidateConfig(t *testing.T) {} // want `TestValidateConfig corresponds to ValidateConfig \(funcs\.go:\d+\) but appears before TestApplyConfig which corresponds to ApplyConfig \(funcs\.go:\d+\)`
+func TestValidateConfig(t *testing.T) {}
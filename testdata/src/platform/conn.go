@@ -0,0 +1,5 @@
+package platform // want package:"testalign source order"
+
+type Conn struct{}
+
+func (c *Conn) Close() error { return nil }
@@ -0,0 +1,5 @@
+package platform
+
+func dial(addr string) (*Conn, error) { return &Conn{}, nil }
+
+func listen(addr string) error { return nil }
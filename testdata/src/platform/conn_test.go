@@ -0,0 +1,11 @@
+package platform
+
+import "testing"
+
+func TestConn_Close(t *testing.T) {}
+
+func Test_pipeName(t *testing.T) {} // want `Test_pipeName corresponds to pipeName \(conn_windows\.go:\d+\) and should be placed after Test_listen which corresponds to listen \(conn_linux\.go:\d+\)`
+
+func Test_dial(t *testing.T) {}
+
+func Test_listen(t *testing.T) {}
@@ -0,0 +1,7 @@
+package platform
+
+import "testing"
+
+func Test_wake(t *testing.T) {}
+
+func Test_poll(t *testing.T) {} // want `Test_poll corresponds to poll \(poller_unix\.go:\d+\) and should be placed before Test_wake which corresponds to wake \(poller_unix\.go:\d+\)`
@@ -0,0 +1,7 @@
+//go:build unix
+
+package platform
+
+func poll() {}
+
+func wake() {}
@@ -0,0 +1,24 @@
+package resolve // want package:"testalign source order"
+
+type Service struct {
+	*Logger
+}
+
+// Svc はServiceのエイリアス。
+type Svc = Service
+
+func (s *(Service)) Start() {}
+
+func (s *Svc) Stop() {}
+
+type Logger struct{}
+
+func (l *Logger) Log(msg string) {}
+
+type A struct{}
+
+func (a A) B_C() {}
+
+type A_B struct{}
+
+func (a A_B) C() {}
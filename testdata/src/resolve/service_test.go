@@ -0,0 +1,11 @@
+package resolve
+
+import "testing"
+
+func TestService_Start(t *testing.T) {}
+
+func TestService_Log(t *testing.T) {}
+
+func TestSvc_Stop(t *testing.T) {} // want `TestSvc_Stop corresponds to Service\.Stop \(service\.go:\d+\) and should be placed after TestService_Start which corresponds to Service\.Start \(service\.go:\d+\)`
+
+func TestA_B_C(t *testing.T) {} // want `TestA_B_C is ambiguous: it may test A\.B_C \(service\.go:\d+\) or A_B\.C \(service\.go:\d+\)`
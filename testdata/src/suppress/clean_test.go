@@ -0,0 +1,9 @@
+//testalign:ignore-file // want `unused //testalign:ignore-file directive: the file has no order violations`
+
+package suppress
+
+import "testing"
+
+func TestQueue_Push_Clean(t *testing.T) {}
+
+func TestQueue_Pop_Clean(t *testing.T) {}
@@ -0,0 +1,9 @@
+//testalign:ignore-file generated ordering
+
+package suppress
+
+import "testing"
+
+func TestQueue_Peek_Legacy(t *testing.T) {}
+
+func TestQueue_Push_Legacy(t *testing.T) {}
@@ -0,0 +1,11 @@
+package suppress // want package:"testalign source order"
+
+type Queue struct{}
+
+func (q *Queue) Push() {}
+
+func (q *Queue) Pop() {}
+
+func (q *Queue) Peek() {}
+
+func (q *Queue) Len() int { return 0 }
@@ -0,0 +1,18 @@
+package suppress
+
+import "testing"
+
+// TestQueue_Len は共通のフィクスチャを用意するため先頭に置く。
+//
+//testalign:ignore shared fixture must run first
+func TestQueue_Len(t *testing.T) {}
+
+func TestQueue_Push(t *testing.T) {}
+
+func TestQueue_Pop(t *testing.T) {} //testalign:ignore stale // want `unused //testalign:ignore directive: TestQueue_Pop has no order violation to suppress`
+
+func TestQueue_Peek(t *testing.T) {}
+
+//testalign:ignore // want `unused //testalign:ignore directive: it is not attached to a function`
+
+var _ = 0
@@ -0,0 +1,7 @@
+package testifysuite // want package:"testalign source order"
+
+type Order struct{}
+
+func (o *Order) Place() {}
+
+func (o *Order) Ship() {}
@@ -0,0 +1,23 @@
+package testifysuite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type base struct{}
+
+type OrderTestSuite struct {
+	base
+}
+
+func TestOrder(t *testing.T) {
+	suite.Run(t, &OrderTestSuite{})
+}
+
+func (s *OrderTestSuite) TestShip() {}
+
+func (s *OrderTestSuite) TestPlace() {} // want `OrderTestSuite.TestPlace corresponds to Order.Place \(order.go:5\) and should be placed after TestOrder which corresponds to Order \(order.go:3\)`
+
+func (s *OrderTestSuite) helper() {}
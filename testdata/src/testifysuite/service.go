@@ -0,0 +1,9 @@
+package testifysuite
+
+type Service struct{}
+
+func (s *Service) Create() {}
+
+func (s *Service) Update() {}
+
+func (s *Service) Delete() {}
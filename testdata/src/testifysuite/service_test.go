@@ -0,0 +1,23 @@
+package testifysuite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceSuite struct {
+	suite.Suite
+}
+
+func TestServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceSuite))
+}
+
+func (s *ServiceSuite) SetupTest() {}
+
+func (s *ServiceSuite) TestCreate() {}
+
+func (s *ServiceSuite) TestDelete() {}
+
+func (s *ServiceSuite) TestUpdate_Conflict() {} // want `ServiceSuite.TestUpdate_Conflict corresponds to Service.Update \(service.go:7\) and should be placed after ServiceSuite.TestCreate which corresponds to Service.Create \(service.go:5\)`
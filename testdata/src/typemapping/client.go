@@ -0,0 +1,5 @@
+package typemapping // want package:"testalign source order"
+
+type Client struct{}
+
+func (c *Client) Do() error { return nil }
@@ -0,0 +1,5 @@
+package typemapping
+
+import "testing"
+
+func TestClient_Do(t *testing.T) {}
@@ -0,0 +1,9 @@
+package typemapping
+
+type Service struct {
+	client *Client
+}
+
+func NewService(c *Client) *Service { return &Service{client: c} }
+
+func (s *Service) Start() error { return nil }
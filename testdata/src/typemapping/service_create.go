@@ -0,0 +1,5 @@
+package typemapping
+
+func (s *Service) Create(name string) error { return s.validate(name) }
+
+func (s *Service) validate(name string) error { return nil }
@@ -0,0 +1,5 @@
+package typemapping
+
+func (s *Service) Find(id int) string { return "" }
+
+func (s *Service) List() []string { return nil }
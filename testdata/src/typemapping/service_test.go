@@ -0,0 +1,15 @@
+package typemapping
+
+import "testing"
+
+func TestNewService(t *testing.T) {}
+
+func TestService_Start(t *testing.T) {}
+
+func TestService_Find(t *testing.T) {} // want `TestService_Find corresponds to Service\.Find \(service_query\.go:\d+\) and should be placed after TestService_validate which corresponds to Service\.validate \(service_create\.go:\d+\)`
+
+func TestService_Create(t *testing.T) {}
+
+func TestService_validate(t *testing.T) {}
+
+func TestService_List(t *testing.T) {}
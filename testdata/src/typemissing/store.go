@@ -0,0 +1,5 @@
+package typemissing // want package:"testalign source order"
+
+type Store struct{}
+
+func (s *Store) Open() error { return nil }
@@ -0,0 +1,7 @@
+package typemissing
+
+import "testing"
+
+func TestStore_Open(t *testing.T) {} // want `Store\.Put \(store_write\.go:\d+\) has no corresponding test in store_test\.go; add it after TestStore_Open`
+
+func TestStore_Delete(t *testing.T) {}
@@ -0,0 +1,8 @@
+package typemissing
+
+func (s *Store) Put(key string) error { return nil }
+
+func (s *Store) Delete(key string) error { return nil }
+
+// Flushはstore_write_test.goでテストしている
+func (s *Store) Flush() error { return nil }
@@ -0,0 +1,5 @@
+package typemissing
+
+import "testing"
+
+func TestStore_Flush(t *testing.T) {}
@@ -0,0 +1,11 @@
+package typeorder // want package:"testalign source order"
+
+type User struct{}
+
+type Order struct{}
+
+func (o *Order) Create() {}
+
+func (u *User) Get() {}
+
+func (u *User) Delete() {}
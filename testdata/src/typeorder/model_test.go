@@ -0,0 +1,9 @@
+package typeorder
+
+import "testing"
+
+func TestOrder_Create(t *testing.T) {} // want `TestOrder_Create corresponds to Order.Create \(model.go:7\) and should be placed after TestUser_Delete which corresponds to User.Delete \(model.go:11\)`
+
+func TestUser_Get(t *testing.T) {}
+
+func TestUser_Delete(t *testing.T) {}
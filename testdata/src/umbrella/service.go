@@ -0,0 +1,21 @@
+package umbrella // want package:"testalign source order"
+
+type Service struct{}
+
+func (s *Service) Create() {}
+
+func (s *Service) Update() {}
+
+func (s *Service) Delete() {}
+
+type Order struct{}
+
+func (o *Order) Place() {}
+
+func (o *Order) Ship() {}
+
+type Queue struct{}
+
+func (q *Queue) Push() {}
+
+func (q *Queue) Pop() {}
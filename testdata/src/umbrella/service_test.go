@@ -0,0 +1,30 @@
+package umbrella
+
+import "testing"
+
+func TestService(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		// ネストしたサブテストは対象外
+		t.Run("Delete", func(t *testing.T) {})
+	})
+
+	t.Run("Delete", func(t *testing.T) {})
+
+	t.Run("Update with conflict", func(t *testing.T) {}) // want `TestService/Update_with_conflict corresponds to Service.Update \(service.go:7\) and should be placed after TestService/Create which corresponds to Service.Create \(service.go:5\)`
+
+	name := "dynamic"
+	t.Run(name, func(t *testing.T) {})
+}
+
+//testalign:ignore 手動で並べている
+func TestOrder(t *testing.T) {
+	t.Run("Ship", func(t *testing.T) {})
+	t.Run("Place", func(t *testing.T) {})
+}
+
+const namePop = "Pop"
+
+func TestQueue(t *testing.T) {
+	t.Run(namePop, func(t *testing.T) {})
+	t.Run("Push", func(t *testing.T) {}) // want `TestQueue/Push corresponds to Queue.Push \(service.go:19\) and should be placed before TestQueue/Pop which corresponds to Queue.Pop \(service.go:21\)`
+}
@@ -0,0 +1,3 @@
+package wrongfile // want package:"testalign source order"
+
+func Issue() {}
@@ -0,0 +1,9 @@
+package wrongfile
+
+type Order struct{}
+
+func (o *Order) Place() {}
+
+func (o *Order) Ship() {}
+
+func (o *Order) Cancel() {}
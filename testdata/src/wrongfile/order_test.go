@@ -0,0 +1,7 @@
+package wrongfile
+
+import "testing"
+
+func TestOrder_Place(t *testing.T) {}
+
+func TestOrder_Cancel(t *testing.T) {}
@@ -0,0 +1,7 @@
+package wrongfile
+
+type User struct{}
+
+func NewUser() *User { return &User{} }
+
+func (u *User) Rename(name string) {}
@@ -0,0 +1,15 @@
+package wrongfile
+
+import "testing"
+
+func TestNewUser(t *testing.T) {}
+
+// TestOrder_Ship は注文の発送を検証する。
+func TestOrder_Ship(t *testing.T) { // want `TestOrder_Ship corresponds to Order.Ship \(order.go:7\) and belongs in order_test.go`
+	var o Order
+	o.Ship()
+}
+
+func TestUser_Rename(t *testing.T) {}
+
+func TestIssue(t *testing.T) {} // want `TestIssue corresponds to Issue \(invoice.go:3\) and belongs in invoice_test.go, which does not exist yet`
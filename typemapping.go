@@ -0,0 +1,96 @@
+package testalign
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ファイル対応の方式。Config.Mapping に指定する。
+const (
+	MappingFile = "file" // テストファイルを対応ルールで求めたソースファイルに対応付ける（既定）
+	MappingType = "type" // テストファイルを名前の一致する型に対応付け、型の宣言とメソッドのあるファイルをまとめる
+)
+
+// typeFamilies は型ごとに、型の宣言とメソッドのあるソースファイルの関数を結合して返す。
+// キーは型名を小文字にしたもの。ファイルは型を宣言したファイルを先頭に、残りをファイル名順に並べ、
+// 各ファイルの中は宣言順とする。型を宣言したファイルが見つからない型は含めない。
+func typeFamilies(allSourceFuncs map[string][]SourceFunc) map[string][]SourceFunc {
+	declFiles := make(map[string]string)
+	methodFiles := make(map[string][]string)
+
+	for _, fileName := range slices.Sorted(maps.Keys(allSourceFuncs)) {
+		for _, sf := range allSourceFuncs[fileName] {
+			switch {
+			case sf.Kind == DeclType:
+				if _, ok := declFiles[sf.Name]; !ok {
+					declFiles[sf.Name] = fileName
+				}
+			case sf.ReceiverType != "":
+				if !slices.Contains(methodFiles[sf.ReceiverType], fileName) {
+					methodFiles[sf.ReceiverType] = append(methodFiles[sf.ReceiverType], fileName)
+				}
+			}
+		}
+	}
+
+	families := make(map[string][]SourceFunc)
+	for _, typ := range slices.Sorted(maps.Keys(declFiles)) {
+		key := strings.ToLower(typ)
+		if _, ok := families[key]; ok {
+			continue
+		}
+
+		files := []string{declFiles[typ]}
+		for _, fileName := range methodFiles[typ] {
+			if fileName != declFiles[typ] {
+				files = append(files, fileName)
+			}
+		}
+
+		var funcs []SourceFunc
+		for _, fileName := range files {
+			funcs = append(funcs, allSourceFuncs[fileName]...)
+		}
+		families[key] = funcs
+	}
+
+	return families
+}
+
+// applyTypeMapping はConfig.Mapping が MappingType の場合に、テストファイルを名前の一致する型に対応付ける。
+// テストファイルに対応するソースファイル名（例: service_test.go → service.go）から拡張子と "_" を除き、
+// 大文字・小文字を区別せずに型名（例: Service）と照合する。TestSuffixes の接尾辞を取り除いた名前も照合する。
+// 一致した型の関数一覧をソースファイル名をキーとしてfamiliesの複製に登録して返し、ファイル単位の対応より優先させる。
+func applyTypeMapping(cfg *Config, testFileNames []string, allSourceFuncs, families map[string][]SourceFunc) map[string][]SourceFunc {
+	if cfg.Mapping != MappingType {
+		return families
+	}
+
+	types := typeFamilies(allSourceFuncs)
+	families = maps.Clone(families)
+	if families == nil {
+		families = make(map[string][]SourceFunc)
+	}
+
+	for _, testFileName := range testFileNames {
+		name := cfg.SourceFileFor(testFileName)
+		stem := strings.TrimSuffix(name, ".go")
+
+		stems := []string{stem}
+		for _, suffix := range cfg.TestSuffixes {
+			if base, ok := strings.CutSuffix(stem, suffix); ok && base != "" {
+				stems = append(stems, base)
+			}
+		}
+
+		for _, s := range stems {
+			if funcs, ok := types[strings.ToLower(strings.ReplaceAll(s, "_", ""))]; ok {
+				families[name] = funcs
+				break
+			}
+		}
+	}
+
+	return families
+}
@@ -0,0 +1,75 @@
+package testalign
+
+import "testing"
+
+func TestTypeFamilies(t *testing.T) {
+	allSourceFuncs := map[string][]SourceFunc{
+		"service.go": {
+			{Name: "Service", Kind: DeclType, FileName: "service.go"},
+			{Name: "Start", ReceiverType: "Service", FileName: "service.go"},
+		},
+		"service_create.go": {
+			{Name: "Create", ReceiverType: "Service", FileName: "service_create.go"},
+			{Name: "validateName", FileName: "service_create.go"},
+		},
+		"a_query.go": {
+			{Name: "Find", ReceiverType: "Service", FileName: "a_query.go"},
+		},
+		"client.go": {
+			{Name: "Client", Kind: DeclType, FileName: "client.go"},
+		},
+		"orphan.go": {
+			{Name: "Run", ReceiverType: "undeclared", FileName: "orphan.go"},
+		},
+	}
+
+	got := typeFamilies(allSourceFuncs)
+
+	// 型を宣言したファイルを先頭に、残りのファイルをファイル名順に並べる
+	want := []string{"Service", "Start", "Find", "Create", "validateName"}
+	funcs := got["service"]
+	if len(funcs) != len(want) {
+		t.Fatalf("service の関数数: got %d, want %d", len(funcs), len(want))
+	}
+	for i, w := range want {
+		if funcs[i].Name != w {
+			t.Errorf("service[%d]: got %s, want %s", i, funcs[i].Name, w)
+		}
+	}
+
+	if len(got["client"]) != 1 {
+		t.Errorf("client の関数数: got %d, want 1", len(got["client"]))
+	}
+
+	if _, ok := got["undeclared"]; ok {
+		t.Error("宣言のない型が含まれている")
+	}
+}
+
+func TestApplyTypeMapping(t *testing.T) {
+	allSourceFuncs := map[string][]SourceFunc{
+		"http_client.go": {{Name: "HTTPClient", Kind: DeclType, FileName: "http_client.go"}},
+		"http_client_do.go": {
+			{Name: "Do", ReceiverType: "HTTPClient", FileName: "http_client_do.go"},
+		},
+	}
+	testFiles := []string{"http_client_test.go", "http_client_bench_test.go", "misc_test.go"}
+
+	cfg := DefaultConfig()
+	if got := applyTypeMapping(cfg, testFiles, allSourceFuncs, nil); len(got) != 0 {
+		t.Errorf("MappingFile: got %d entries, want 0", len(got))
+	}
+
+	cfg.Mapping = MappingType
+	got := applyTypeMapping(cfg, testFiles, allSourceFuncs, nil)
+
+	for _, name := range []string{"http_client.go", "http_client_bench.go"} {
+		if len(got[name]) != 2 {
+			t.Errorf("%s の関数数: got %d, want 2", name, len(got[name]))
+		}
+	}
+
+	if _, ok := got["misc.go"]; ok {
+		t.Error("型に一致しないテストファイルが対応付けられている")
+	}
+}
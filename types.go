@@ -5,39 +5,102 @@ import (
 	"strings"
 )
 
-// SourceFunc はソースファイル内の関数またはメソッド宣言を表す。
+// DeclKind はソース宣言の種類を表す。
+type DeclKind int
+
+const (
+	DeclFunc  DeclKind = iota // 関数・メソッド
+	DeclType                  // 型
+	DeclConst                 // 定数
+	DeclVar                   // 変数
+)
+
+// SourceFunc はソースファイル内の関数・メソッド宣言を表す。
+// 型・定数・変数の宣言も、テスト関数の並び順の基準として同じ形式で表す。
 type SourceFunc struct {
-	Name         string    // 関数名
+	Name         string    // 関数名（型・定数・変数の場合はその名前）
 	ReceiverType string    // レシーバー型名（関数の場合は空）
-	Pos          token.Pos // 宣言位置
+	Pos          token.Pos // 宣言位置（型・定数・変数の場合は名前の位置）
 	FileName     string    // ファイル名
+	Lines        int       // 宣言の行数（funcキーワードから閉じ括弧まで）
+	Kind         DeclKind  // 宣言の種類
+	Constructs   string    // コンストラクタの場合に生成する型名（Config.Constructors が有効な場合のみ設定される）
+	AltReceivers []string  // テスト名に使える別のレシーバー型名（エイリアス、昇格先の型。Config.Resolve が ResolveTypes の場合のみ設定される）
 }
 
 // QualifiedName はレシーバー型を含む修飾名を返す。
 // メソッドの場合は "ReceiverType_Name"、関数の場合は "Name" を返す。
 // 非公開関数の場合は "_name" の形式を返す。
 func (sf SourceFunc) QualifiedName() string {
+	return defaultNaming.QualifiedName(sf)
+}
+
+// QualifiedName は命名規則に従ってソース関数の修飾名を返す。
+//
+// メソッドの場合、NamingCamel では "ReceiverTypeName"（各要素の先頭を大文字にして連結）、
+// NamingGotests では非公開の型に限り UnexportedPrefix を付けた "_receiverType_Name"、
+// それ以外は "ReceiverType_Name" を返す。
+// 関数の場合は "Name"、非公開関数は "_name" の形式を返す。
+func (n Naming) QualifiedName(sf SourceFunc) string {
 	if sf.ReceiverType != "" {
-		return sf.ReceiverType + "_" + sf.Name
+		switch n.Style {
+		case NamingCamel:
+			return upperFirst(sf.ReceiverType) + upperFirst(sf.Name)
+		case NamingGotests:
+			if isLowerFirst(sf.ReceiverType) {
+				return n.UnexportedPrefix + sf.ReceiverType + n.Separator + sf.Name
+			}
+		}
+
+		return sf.ReceiverType + n.Separator + sf.Name
 	}
 
-	// 非公開関数の場合、先頭が小文字なので "_name" 形式にする
-	if len(sf.Name) > 0 && sf.Name[0] >= 'a' && sf.Name[0] <= 'z' {
-		return "_" + sf.Name
+	// 非公開関数の場合、先頭が小文字なのでプレフィックスを付ける
+	if isLowerFirst(sf.Name) {
+		return n.UnexportedPrefix + sf.Name
 	}
 
 	return sf.Name
 }
 
+// isLowerFirst は名前の先頭がASCIIの小文字か判定する。
+func isLowerFirst(name string) bool {
+	return len(name) > 0 && name[0] >= 'a' && name[0] <= 'z'
+}
+
+// upperFirst は名前の先頭のASCII小文字を大文字にして返す。
+func upperFirst(name string) string {
+	if !isLowerFirst(name) {
+		return name
+	}
+
+	return string(name[0]-'a'+'A') + name[1:]
+}
+
 // TestFunc はテストファイル内のテスト関数宣言を表す。
 type TestFunc struct {
-	Name     string    // テスト関数名（例: "TestService_Create"）
-	Pos      token.Pos // 宣言位置
+	Name     string    // テスト関数名（例: "TestService_Create"）、サブテストの場合はサブテスト名
+	Prefix   string    // テストプレフィックス（空の場合は既定のプレフィックスから判定）
+	Pos      token.Pos // 宣言位置（サブテストの場合はt.Runの呼び出し位置）
 	FileName string    // ファイル名
+
+	// testifyスイートのメソッドとt.Runサブテストの場合のみ設定される
+	Suite      string // スイート型名（例: "ServiceSuite"）
+	Parent     string // サブテストを含むテスト関数名（例: "TestService"）
+	TargetType string // テスト対象の型名（例: "Service"）
 }
 
 // TargetName はテストプレフィックス（Test/Benchmark/Fuzz/Example）を除去した名前を返す。
+// Prefixが設定されている場合はそのプレフィックスを除去する。サブテストの場合はサブテスト名をそのまま返す。
 func (tf TestFunc) TargetName() string {
+	if tf.Parent != "" {
+		return tf.Name
+	}
+
+	if tf.Prefix != "" {
+		return strings.TrimPrefix(tf.Name, tf.Prefix)
+	}
+
 	for _, prefix := range []string{"Test", "Benchmark", "Fuzz", "Example"} {
 		if strings.HasPrefix(tf.Name, prefix) {
 			rest := tf.Name[len(prefix):]
@@ -65,15 +128,17 @@ type MatchResult struct {
 
 // OrderViolation は順序違反の情報を表す。
 type OrderViolation struct {
-	TestFunc      TestFunc     // 順序違反のテスト関数
-	SourceFunc    SourceFunc   // 対応するソース関数
-	PrecedingTest *MatchResult // ソース順序的に後にあるべきテスト関数
+	TestFunc   TestFunc     // 順序違反のテスト関数
+	SourceFunc SourceFunc   // 対応するソース関数
+	After      *MatchResult // 移動先（このテスト関数の直後に移動する）
+	Before     *MatchResult // 移動先（Afterがnilの場合、このテスト関数の直前に移動する）
 }
 
 // SourceOrderFact は外部テストパッケージ用のFactとしてエクスポートされる。
-// ソースファイルごとの関数一覧を保持する。
+// ソースファイルごとの関数一覧と、プラットフォーム別のソースファイルをまとめた関数一覧を保持する。
 type SourceOrderFact struct {
 	FileToFuncs map[string][]SourceFunc
+	Families    map[string][]SourceFunc // 接尾辞を除いたファイル名（例: conn.go）ごとの関数一覧
 }
 
 func (*SourceOrderFact) AFact() {}
@@ -0,0 +1,454 @@
+package testalign
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Misplacement はマッピングされたソースファイルとは別のファイルで宣言された
+// ソース関数に対応するテスト関数を表す。
+type Misplacement struct {
+	TestFunc   TestFunc
+	SourceFunc SourceFunc // 対応するソース関数
+}
+
+// FindMisplacedTests はテスト関数のうち、マッピングされたソースファイルの関数（mappedFuncs）には
+// 対応せず、パッケージ内の別のソースファイルの関数に対応するものを返す。
+// mappedFuncsが空の場合（対応するソースファイルがない場合）は、パッケージ内の関数に対応する
+// すべてのテスト関数を返す。
+func FindMisplacedTests(testFuncs []TestFunc, mappedFuncs, packageFuncs []SourceFunc, matcher Matcher) []Misplacement {
+	var misplaced []Misplacement
+
+	for _, tf := range testFuncs {
+		if len(mappedFuncs) > 0 && matcher.Match(tf, mappedFuncs) != nil {
+			continue
+		}
+
+		sf := matcher.Match(tf, packageFuncs)
+		if sf == nil {
+			continue
+		}
+
+		// mappedFuncsはプラットフォーム別の複数のファイルにまたがる場合がある
+		if slices.ContainsFunc(mappedFuncs, func(m SourceFunc) bool { return m.FileName == sf.FileName }) {
+			continue
+		}
+
+		misplaced = append(misplaced, Misplacement{TestFunc: tf, SourceFunc: *sf})
+	}
+
+	return misplaced
+}
+
+// checkWrongFile は別のテストファイルに置くべきテスト関数を報告する。
+//
+// 移動先は、ソース関数のファイルに対応する既存のテストファイル（なければ対応ルールから求めたファイル名）。
+// テストファイルに対応するソースファイルがない場合は、移動先のテストファイルが存在するものだけを報告する。
+// 移動先がパッケージ内に存在する場合は、テスト関数を移動するSuggestedFixを添付する。
+func checkWrongFile(pass *analysis.Pass, cfg *Config, testFileName string, testFiles map[string]*ast.File, testFuncs []TestFunc, allSourceFuncs, families map[string][]SourceFunc, suites map[string]string) error {
+	mappedFuncs := mappedSourceFuncs(cfg.sourceFileIn(testFileName, allSourceFuncs, families), allSourceFuncs, families)
+	packageFuncs := concatSourceFuncs(allSourceFuncs)
+
+	for _, m := range FindMisplacedTests(testFuncs, mappedFuncs, packageFuncs, cfg.matcher()) {
+		destName, exists := destTestFile(cfg, m.SourceFunc.FileName, testFiles)
+		if destName == testFileName || (len(mappedFuncs) == 0 && !exists) || (!exists && testFiles[destName] != nil) {
+			continue
+		}
+
+		diag := analysis.Diagnostic{
+			Pos: m.TestFunc.Pos,
+			Message: fmt.Sprintf("%s corresponds to %s (%s) and belongs in %s",
+				formatTestRef(m.TestFunc), formatFuncRef(m.SourceFunc), formatSourcePos(pass.Fset, m.SourceFunc), destName),
+		}
+
+		if exists {
+			fix, ok, err := buildCrossFileMoveFix(pass, cfg, testFiles[testFileName], testFiles[destName], m, allSourceFuncs[m.SourceFunc.FileName], suites)
+			if err != nil {
+				return err
+			}
+			if ok {
+				diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+			}
+		} else {
+			// SuggestedFixでは新しいファイルを作成できないため、移動は fmt サブコマンドに任せる
+			diag.Message += ", which does not exist yet"
+		}
+
+		pass.Report(diag)
+	}
+
+	return nil
+}
+
+// destTestFile はソースファイルに対応するテストファイル名を返す。
+// パッケージ内に対応する既存のテストファイルがある場合はそれ（複数あればファイル名順で最初のもの）を返し、
+// existsをtrueにする。
+func destTestFile(cfg *Config, sourceFileName string, testFiles map[string]*ast.File) (name string, exists bool) {
+	for _, name := range slices.Sorted(maps.Keys(testFiles)) {
+		if cfg.SourceFileFor(name) == sourceFileName {
+			return name, true
+		}
+	}
+
+	return cfg.TestFileFor(sourceFileName), false
+}
+
+// buildCrossFileMoveFix はテスト関数を別のテストファイルへ移動するSuggestedFixを生成する。
+// 移動先では、ソース関数の宣言順序に沿う位置（対応するテスト関数がなければファイル末尾）に挿入する。
+//
+// 以下の場合は修正を生成しない（okがfalse）。
+//   - 移動元と移動先のパッケージ名が異なる（外部テストパッケージとの間の移動）
+//   - インポート宣言の編集が必要になる（canMoveTest を参照）
+func buildCrossFileMoveFix(pass *analysis.Pass, cfg *Config, origin, dest *ast.File, m Misplacement, destSourceFuncs []SourceFunc, suites map[string]string) (analysis.SuggestedFix, bool, error) {
+	decl := findFuncDecl(origin, m.TestFunc.Pos)
+	if decl == nil || origin.Name.Name != dest.Name.Name {
+		return analysis.SuggestedFix{}, false, nil
+	}
+
+	if !canMoveTest(origin, dest, decl) {
+		return analysis.SuggestedFix{}, false, nil
+	}
+
+	originTF := pass.Fset.File(origin.Pos())
+	destTF := pass.Fset.File(dest.Pos())
+
+	originSrc, err := pass.ReadFile(originTF.Name())
+	if err != nil {
+		return analysis.SuggestedFix{}, false, err
+	}
+
+	destSrc, err := pass.ReadFile(destTF.Name())
+	if err != nil {
+		return analysis.SuggestedFix{}, false, err
+	}
+
+	text := declText(originTF, originSrc, decl)
+	delStart, delEnd := declSpan(originTF, originSrc, decl)
+
+	// 移動先での挿入位置を決める
+	destMatches := matchTestFuncs(extractTestFuncs(dest, pass.Fset, cfg.TestPrefixes, suites), destSourceFuncs, cfg.matcher())
+	anchor, after := insertionAnchor(destMatches, m.SourceFunc, destSourceFuncs)
+
+	end := len(destSrc)
+	if anchor != nil {
+		anchorDecl := findFuncDecl(dest, anchor.TestFunc.Pos)
+		if anchorDecl == nil {
+			return analysis.SuggestedFix{}, false, nil
+		}
+
+		if after {
+			end = lineEnd(destSrc, destTF.Offset(anchorDecl.End()))
+		} else {
+			end = destTF.Offset(lineStart(destTF, declStart(anchorDecl)))
+		}
+	}
+
+	if anchor != nil && !after {
+		text = append(text, '\n')
+	} else {
+		if end == len(destSrc) && !bytes.HasSuffix(destSrc, []byte("\n")) {
+			text = append([]byte("\n"), text...)
+		}
+		text = append([]byte("\n"), text...)
+	}
+
+	insertPos := destTF.Pos(end)
+
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("Move %s to %s", formatTestRef(m.TestFunc), filepath.Base(destTF.Name())),
+		TextEdits: []analysis.TextEdit{
+			{Pos: insertPos, End: insertPos, NewText: text},
+			{Pos: originTF.Pos(delStart), End: originTF.Pos(delEnd)},
+		},
+	}, true, nil
+}
+
+// insertionAnchor はsfに対応するテスト関数を挿入する位置の基準となるテスト関数を返す。
+// ソースインデックスがsf以下の最後のテスト関数があればその直後（afterがtrue）、
+// なければソースインデックスが最小のテスト関数の直前を返す。
+// マッチしたテスト関数がない場合はnilを返す。
+func insertionAnchor(matches []MatchResult, sf SourceFunc, sourceFuncs []SourceFunc) (anchor *MatchResult, after bool) {
+	sourceIndex := buildSourceIndex(sourceFuncs)
+	idx := sourceIndex[sf.Pos]
+
+	for i := range matches {
+		m := &matches[i]
+		if m.SourceFunc == nil {
+			continue
+		}
+
+		j, ok := sourceIndex[m.SourceFunc.Pos]
+		if !ok {
+			continue
+		}
+
+		if j <= idx {
+			anchor, after = m, true
+		} else if anchor == nil {
+			anchor = m
+		}
+	}
+
+	return anchor, after
+}
+
+// canMoveTest はインポート宣言を編集せずにテスト関数declをoriginからdestへ移動できるか判定する。
+// destが、declの参照するパッケージをすべてインポートしていて、
+// declを取り除いてもoriginに未使用のインポートが残らない場合にtrueを返す。
+// destがnilの場合（新しいファイルを作成する場合）はorigin側だけを確認する。
+func canMoveTest(origin, dest *ast.File, decl *ast.FuncDecl) bool {
+	used := importsUsedIn(origin, decl)
+
+	if dest != nil {
+		destImports := importPaths(dest)
+		for path := range used {
+			if !destImports[path] {
+				return false
+			}
+		}
+	}
+
+	for path := range used {
+		usedElsewhere := false
+		for _, d := range origin.Decls {
+			if d != decl && importsUsedIn(origin, d)[path] != nil {
+				usedElsewhere = true
+				break
+			}
+		}
+
+		if !usedElsewhere {
+			return false
+		}
+	}
+
+	return true
+}
+
+// importsUsedIn はfileのインポート宣言のうち、ノード内の "pkg.Name" 形式の参照で使われているものを
+// パスをキーとして返す。型情報を使わず、参照名とインポート名の一致だけで判定する。
+func importsUsedIn(file *ast.File, node ast.Node) map[string]*ast.ImportSpec {
+	byName := make(map[string]*ast.ImportSpec)
+	for _, spec := range file.Imports {
+		byName[importName(spec)] = spec
+	}
+
+	used := make(map[string]*ast.ImportSpec)
+	ast.Inspect(node, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if id, ok := sel.X.(*ast.Ident); ok {
+			if spec := byName[id.Name]; spec != nil {
+				p, _ := strconv.Unquote(spec.Path.Value)
+				used[p] = spec
+			}
+		}
+
+		return true
+	})
+
+	return used
+}
+
+// importName はインポート宣言でパッケージを参照する名前を返す。
+// 別名がない場合はパスの最後の要素を使い、"/v2" や ".v3" のようなメジャーバージョンは取り除く。
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+
+	p, _ := strconv.Unquote(spec.Path.Value)
+	name := path.Base(p)
+	if isMajorVersion(name) && path.Dir(p) != "." {
+		name = path.Base(path.Dir(p))
+	}
+
+	if i := strings.LastIndex(name, "."); i > 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
+	}
+
+	return name
+}
+
+// isMajorVersion は "v2" のようなメジャーバージョン要素か判定する。
+func isMajorVersion(s string) bool {
+	digits, ok := strings.CutPrefix(s, "v")
+	if !ok || digits == "" {
+		return false
+	}
+
+	_, err := strconv.Atoi(digits)
+
+	return err == nil
+}
+
+// importPaths はファイルがインポートしているパッケージのパスを返す。
+func importPaths(file *ast.File) map[string]bool {
+	paths := make(map[string]bool)
+	for _, spec := range file.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err == nil {
+			paths[p] = true
+		}
+	}
+
+	return paths
+}
+
+// moveMisplacedTests は別のテストファイルに置くべきテスト関数を移動した後のテストファイルの内容を返す。
+//
+// srcsはテストファイルのパスから内容へのマッピング。移動先のテストファイルが存在しない場合は、
+// 移動元と同じディレクトリ・パッケージにファイルを作成し、必要なインポート宣言とともに戻り値に追加する。
+// 移動先に追記したテスト関数はファイル末尾に置かれるため、並べ替えは呼び出し側で行う。
+func moveMisplacedTests(fset *token.FileSet, testFiles []*ast.File, srcs map[string][]byte, allSourceFuncs, families map[string][]SourceFunc, cfg *Config) map[string][]byte {
+	type newFile struct {
+		pkg     string
+		imports map[string]string // パスからインポート宣言のテキストへのマッピング
+		decls   [][]byte
+	}
+
+	byName := make(map[string]*ast.File)
+	for _, file := range testFiles {
+		byName[filepath.Base(fset.File(file.Pos()).Name())] = file
+	}
+
+	packageFuncs := concatSourceFuncs(allSourceFuncs)
+	suites := findSuiteTypes(testFiles, cfg.Suite)
+	removals := make(map[string][][2]int)
+	appends := make(map[string][][]byte)
+	created := make(map[string]*newFile)
+
+	for _, name := range slices.Sorted(maps.Keys(byName)) {
+		origin := byName[name]
+		tf := fset.File(origin.Pos())
+		src := srcs[tf.Name()]
+		mappedFuncs := mappedSourceFuncs(cfg.sourceFileIn(name, allSourceFuncs, families), allSourceFuncs, families)
+		testFuncs := extractTestFuncs(origin, fset, cfg.TestPrefixes, suites)
+
+		for _, m := range FindMisplacedTests(testFuncs, mappedFuncs, packageFuncs, cfg.matcher()) {
+			destName, exists := destTestFile(cfg, m.SourceFunc.FileName, byName)
+			if destName == name || (len(mappedFuncs) == 0 && !exists) || (!exists && byName[destName] != nil) {
+				continue
+			}
+
+			decl := findFuncDecl(origin, m.TestFunc.Pos)
+			if decl == nil {
+				continue
+			}
+
+			var dest *ast.File
+			if exists {
+				dest = byName[destName]
+				if dest.Name.Name != origin.Name.Name {
+					continue
+				}
+			}
+
+			destPath := filepath.Join(filepath.Dir(tf.Name()), destName)
+			nf := created[destPath]
+			if !exists && nf != nil && nf.pkg != origin.Name.Name {
+				continue
+			}
+
+			if !canMoveTest(origin, dest, decl) {
+				continue
+			}
+
+			text := declText(tf, src, decl)
+			if !bytes.HasSuffix(text, []byte("\n")) {
+				text = append(text, '\n')
+			}
+
+			start, end := declSpan(tf, src, decl)
+			removals[tf.Name()] = append(removals[tf.Name()], [2]int{start, end})
+
+			if exists {
+				appends[destPath] = append(appends[destPath], text)
+				continue
+			}
+
+			if nf == nil {
+				nf = &newFile{pkg: origin.Name.Name, imports: make(map[string]string)}
+				created[destPath] = nf
+			}
+
+			for p, spec := range importsUsedIn(origin, decl) {
+				nf.imports[p] = string(src[tf.Offset(spec.Pos()):tf.Offset(spec.End())])
+			}
+			nf.decls = append(nf.decls, text)
+		}
+	}
+
+	result := make(map[string][]byte, len(srcs)+len(created))
+	for path, src := range srcs {
+		out := removeSpans(src, removals[path])
+		for _, text := range appends[path] {
+			if len(out) > 0 && !bytes.HasSuffix(out, []byte("\n")) {
+				out = append(out, '\n')
+			}
+			out = append(append(out, '\n'), text...)
+		}
+
+		result[path] = out
+	}
+
+	for path, nf := range created {
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "package %s\n", nf.pkg)
+
+		if len(nf.imports) == 1 {
+			for _, spec := range nf.imports {
+				fmt.Fprintf(&buf, "\nimport %s\n", spec)
+			}
+		} else if len(nf.imports) > 1 {
+			buf.WriteString("\nimport (\n")
+			for _, p := range slices.Sorted(maps.Keys(nf.imports)) {
+				fmt.Fprintf(&buf, "\t%s\n", nf.imports[p])
+			}
+			buf.WriteString(")\n")
+		}
+
+		for _, text := range nf.decls {
+			buf.WriteString("\n")
+			buf.Write(text)
+		}
+
+		result[path] = buf.Bytes()
+	}
+
+	return result
+}
+
+// removeSpans はsrcからバイト範囲spansを取り除いた内容を返す。重なり合う範囲は1つにまとめる。
+func removeSpans(src []byte, spans [][2]int) []byte {
+	if len(spans) == 0 {
+		return src
+	}
+
+	spans = slices.Clone(spans)
+	slices.SortFunc(spans, func(a, b [2]int) int {
+		return a[0] - b[0]
+	})
+
+	var out []byte
+	last := 0
+	for _, s := range spans {
+		start := max(s[0], last)
+		out = append(out, src[last:start]...)
+		last = max(s[1], last)
+	}
+
+	return append(out, src[last:]...)
+}
@@ -0,0 +1,131 @@
+package testalign
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestFindMisplacedTests(t *testing.T) {
+	packageFuncs := []SourceFunc{
+		{Name: "Place", ReceiverType: "Order", FileName: "order.go"},
+		{Name: "Cancel", ReceiverType: "Order", FileName: "order.go"},
+		{Name: "NewUser", FileName: "user.go"},
+		{Name: "Rename", ReceiverType: "User", FileName: "user.go"},
+	}
+	testFuncs := []TestFunc{
+		{Name: "TestNewUser"},
+		{Name: "TestOrder_Cancel_Twice"},
+		{Name: "TestUser_Rename"},
+		{Name: "TestIntegration"},
+	}
+
+	t.Run("対応するソースファイルがある場合", func(t *testing.T) {
+		got := FindMisplacedTests(testFuncs, packageFuncs[2:], packageFuncs, NamingMatcher{Naming: defaultNaming})
+
+		if len(got) != 1 {
+			t.Fatalf("誤配置数: got %d, want 1", len(got))
+		}
+		if got[0].TestFunc.Name != "TestOrder_Cancel_Twice" || got[0].SourceFunc.Name != "Cancel" {
+			t.Errorf("got %s → %s, want TestOrder_Cancel_Twice → Cancel", got[0].TestFunc.Name, got[0].SourceFunc.Name)
+		}
+	})
+
+	t.Run("対応するソースファイルがない場合", func(t *testing.T) {
+		got := FindMisplacedTests(testFuncs, nil, packageFuncs, NamingMatcher{Naming: defaultNaming})
+
+		// パッケージ内の関数に対応するテスト関数はすべて対象
+		if len(got) != 3 {
+			t.Errorf("誤配置数: got %d, want 3", len(got))
+		}
+	})
+}
+
+func TestCanMoveTest(t *testing.T) {
+	parse := func(name, src string) *ast.File {
+		file, err := parser.ParseFile(token.NewFileSet(), name, src, 0)
+		if err != nil {
+			t.Fatalf("パース失敗: %v", err)
+		}
+
+		return file
+	}
+
+	origin := parse("user_test.go", `package example
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUser_Rename(t *testing.T) { _ = strings.ToUpper("a") }
+
+func TestOrder_Cancel(t *testing.T) {}
+
+func TestOrder_Place(t *testing.T) { _ = strings.ToLower("A") }
+`)
+	dest := parse("order_test.go", `package example
+
+import "testing"
+`)
+
+	tests := []struct {
+		name string
+		dest *ast.File
+		want bool
+	}{
+		// testingは移動元の他の関数でも使われている
+		{"TestOrder_Cancel", dest, true},
+		// 移動先がstringsをインポートしていない
+		{"TestOrder_Place", dest, false},
+		// 新しいファイルには必要なインポートを追加できる
+		{"TestOrder_Place", nil, true},
+	}
+
+	for _, tt := range tests {
+		var decl *ast.FuncDecl
+		for _, d := range origin.Decls {
+			if fd, ok := d.(*ast.FuncDecl); ok && fd.Name.Name == tt.name {
+				decl = fd
+			}
+		}
+
+		if got := canMoveTest(origin, tt.dest, decl); got != tt.want {
+			t.Errorf("canMoveTest(%s, dest=%v): got %v, want %v", tt.name, tt.dest != nil, got, tt.want)
+		}
+	}
+}
+
+func TestImportName(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{`"testing"`, "testing"},
+		{`"net/http"`, "http"},
+		{`yaml "gopkg.in/yaml.v3"`, "yaml"},
+		{`"gopkg.in/yaml.v3"`, "yaml"},
+		{`"github.com/example/lib/v2"`, "lib"},
+	}
+
+	for _, tt := range tests {
+		file, err := parser.ParseFile(token.NewFileSet(), "x.go", "package x\nimport "+tt.spec+"\n", parser.ImportsOnly)
+		if err != nil {
+			t.Fatalf("パース失敗: %v", err)
+		}
+
+		if got := importName(file.Imports[0]); got != tt.want {
+			t.Errorf("importName(%s): got %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestRemoveSpans(t *testing.T) {
+	src := []byte("0123456789")
+
+	got := removeSpans(src, [][2]int{{6, 8}, {1, 3}, {2, 4}})
+	if string(got) != "04589" {
+		t.Errorf("got %q, want %q", got, "04589")
+	}
+}